@@ -1,7 +1,10 @@
 #!/bin/bash
 
 # API endpoint
-API_URL="http://localhost:8080/api/employees"
+API_URL="http://localhost:8080/api/employees/bulk/"
+
+# Bearer token of an hr or admin user, e.g. TOKEN=xxx ./create_indonesian_employees.sh
+TOKEN=${TOKEN:?"TOKEN must be set"}
 
 # Arrays of Indonesian names and tech-related positions
 names=("Budi Santoso" "Agus Wijaya" "Siti Nurhaliza" "Ahmad Rian" "Dewi Lestari" "Rini Handayani" "Bagus Pratama" "Dian Sastro" "Taufik Hidayat" "Indra Gunawan" "Lestari Yulianti" "Yusuf Maulana" "Eka Putra" "Sri Lestari" "Nur Aisyah" "Rudy Hartono" "Febrianto Surya" "Cindy Rahma" "Wulan Pertiwi" "Agung Nugroho")
@@ -12,21 +15,25 @@ generate_salary() {
   echo $((5000000 + RANDOM % 15000001))
 }
 
-# Loop to create 100 employees
+# Build the 100 employees as the items of one bulk request
+items=""
 for i in {1..100}; do
   # Select random name and position
   name=${names[$RANDOM % ${#names[@]}]}
   position=${positions[$RANDOM % ${#positions[@]}]}
   salary=$(generate_salary)
 
-  # Send POST request to create the employee
-  curl -X POST $API_URL \
-    -H "Content-Type: application/json" \
-    -d '{
-      "name": "'"$name"'",
-      "position": "'"$position"'",
-      "salary": '"$salary"'
-    }'
-
-  echo "Created employee $i: $name - $position with salary $salary IDR"
-done
\ No newline at end of file
+  if [ -n "$items" ]; then
+    items+=","
+  fi
+  items+='{"name": "'"$name"'", "position": "'"$position"'", "salary": '"$salary"'}'
+done
+
+# Send a single POST request creating every employee in one transaction
+curl -X POST $API_URL \
+  -H "Content-Type: application/json" \
+  -H "Authorization: Bearer $TOKEN" \
+  -d '{"items": ['"$items"']}'
+
+echo
+echo "Created 100 employees"
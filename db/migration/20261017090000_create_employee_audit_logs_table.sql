@@ -0,0 +1,30 @@
+-- +migrate Up notransaction
+CREATE TABLE employee_audit_logs (
+    id BIGSERIAL NOT NULL,
+    employee_id bigint NOT NULL,
+    actor text NOT NULL,
+    action text NOT NULL,
+    before jsonb NULL,
+    after jsonb NULL,
+    created_at timestamptz NOT NULL,
+    CONSTRAINT employee_audit_logs_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX employee_audit_logs_employee_id_created_at_idx ON employee_audit_logs (employee_id, created_at DESC);
+
+-- +migrate StatementBegin
+CREATE FUNCTION employee_audit_logs_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'employee_audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+CREATE TRIGGER employee_audit_logs_append_only
+    BEFORE UPDATE OR DELETE ON employee_audit_logs
+    FOR EACH ROW EXECUTE FUNCTION employee_audit_logs_append_only();
+
+-- +migrate Down
+DROP TRIGGER employee_audit_logs_append_only ON employee_audit_logs;
+DROP FUNCTION employee_audit_logs_append_only();
+DROP TABLE employee_audit_logs;
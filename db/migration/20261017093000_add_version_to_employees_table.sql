@@ -0,0 +1,5 @@
+-- +migrate Up notransaction
+ALTER TABLE employees ADD COLUMN version bigint NOT NULL DEFAULT 1;
+
+-- +migrate Down
+ALTER TABLE employees DROP COLUMN version;
@@ -0,0 +1,8 @@
+-- +migrate Up notransaction
+ALTER TABLE employees ADD COLUMN external_key text NULL;
+
+CREATE UNIQUE INDEX employees_external_key_uniq_idx ON employees (external_key) WHERE external_key IS NOT NULL AND deleted_at IS NULL;
+
+-- +migrate Down
+DROP INDEX employees_external_key_uniq_idx;
+ALTER TABLE employees DROP COLUMN external_key;
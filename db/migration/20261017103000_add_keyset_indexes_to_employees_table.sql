@@ -0,0 +1,7 @@
+-- +migrate Up notransaction
+CREATE INDEX employees_created_at_id_idx ON employees (created_at, id);
+CREATE INDEX employees_updated_at_id_idx ON employees (updated_at, id);
+
+-- +migrate Down
+DROP INDEX employees_created_at_id_idx;
+DROP INDEX employees_updated_at_id_idx;
@@ -0,0 +1,14 @@
+-- +migrate Up notransaction
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX employees_name_trgm_idx ON employees USING gin (name gin_trgm_ops);
+
+ALTER TABLE employees ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (to_tsvector('simple', name || ' ' || position)) STORED;
+
+CREATE INDEX employees_search_vector_idx ON employees USING gin (search_vector);
+
+-- +migrate Down
+DROP INDEX employees_search_vector_idx;
+ALTER TABLE employees DROP COLUMN search_vector;
+DROP INDEX employees_name_trgm_idx;
@@ -0,0 +1,5 @@
+-- +migrate Up notransaction
+CREATE INDEX employees_position_trgm_idx ON employees USING gin (position gin_trgm_ops);
+
+-- +migrate Down
+DROP INDEX employees_position_trgm_idx;
@@ -0,0 +1,21 @@
+-- +migrate Up notransaction
+CREATE TABLE departments (
+    id BIGSERIAL NOT NULL,
+    name text NOT NULL,
+    description text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    deleted_at timestamptz NULL,
+    CONSTRAINT departments_pkey PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX departments_name_uniq_idx ON departments (lower(name)) WHERE deleted_at IS NULL;
+
+ALTER TABLE employees ADD COLUMN department_id BIGINT NULL REFERENCES departments (id);
+
+CREATE INDEX employees_department_id_idx ON employees (department_id) WHERE deleted_at IS NULL;
+
+-- +migrate Down
+DROP INDEX employees_department_id_idx;
+ALTER TABLE employees DROP COLUMN department_id;
+DROP TABLE departments;
@@ -0,0 +1,11 @@
+-- +migrate Up notransaction
+ALTER TABLE employees ADD COLUMN manager_id BIGINT NULL REFERENCES employees (id);
+
+ALTER TABLE employees ADD CONSTRAINT employees_manager_id_not_self_chk CHECK (manager_id <> id);
+
+CREATE INDEX employees_manager_id_idx ON employees (manager_id) WHERE deleted_at IS NULL;
+
+-- +migrate Down
+DROP INDEX employees_manager_id_idx;
+ALTER TABLE employees DROP CONSTRAINT employees_manager_id_not_self_chk;
+ALTER TABLE employees DROP COLUMN manager_id;
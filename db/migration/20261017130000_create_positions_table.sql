@@ -0,0 +1,45 @@
+-- +migrate Up notransaction
+CREATE TABLE positions (
+    id BIGSERIAL NOT NULL,
+    code text NOT NULL,
+    title text NOT NULL,
+    level integer NOT NULL DEFAULT 1,
+    min_salary float8 NOT NULL DEFAULT 0,
+    max_salary float8 NOT NULL DEFAULT 0,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    deleted_at timestamptz NULL,
+    CONSTRAINT positions_pkey PRIMARY KEY (id),
+    CONSTRAINT positions_level_check CHECK (level > 0),
+    CONSTRAINT positions_salary_band_check CHECK (min_salary >= 0 AND min_salary <= max_salary)
+);
+
+CREATE UNIQUE INDEX positions_code_uniq_idx ON positions (upper(code)) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX positions_title_uniq_idx ON positions (lower(title)) WHERE deleted_at IS NULL;
+
+-- Seed the catalog from the positions the employees already hold, the band spans their current salaries
+-- so nobody ends up out of band. The code is derived from the title and suffixed when two titles collide.
+INSERT INTO positions (code, title, level, min_salary, max_salary, created_at, updated_at)
+SELECT
+    CASE WHEN row_number() OVER (PARTITION BY base_code ORDER BY title) = 1 THEN base_code
+         ELSE base_code || '_' || row_number() OVER (PARTITION BY base_code ORDER BY title)
+    END,
+    title, 1, GREATEST(min_salary, 0), GREATEST(max_salary, 0), now(), now()
+FROM (
+    SELECT
+        min(trim(position)) AS title,
+        coalesce(nullif(upper(trim(BOTH '_' FROM regexp_replace(min(trim(position)), '[^a-zA-Z0-9]+', '_', 'g'))), ''), 'POSITION') AS base_code,
+        min(salary) AS min_salary,
+        max(salary) AS max_salary
+    FROM employees
+    WHERE deleted_at IS NULL AND trim(position) <> ''
+    GROUP BY lower(trim(position))
+) held;
+
+-- Align the employees on the catalog titles, the positions are matched on them from now on
+UPDATE employees SET position = positions.title
+FROM positions
+WHERE lower(trim(employees.position)) = lower(positions.title) AND employees.position <> positions.title;
+
+-- +migrate Down
+DROP TABLE positions;
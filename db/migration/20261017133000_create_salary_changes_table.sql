@@ -0,0 +1,24 @@
+-- +migrate Up notransaction
+CREATE TABLE salary_changes (
+    id BIGSERIAL NOT NULL,
+    employee_id BIGINT NOT NULL REFERENCES employees (id),
+    amount float8 NOT NULL,
+    effective_from date NOT NULL,
+    reason text NOT NULL DEFAULT '',
+    approved_by text NOT NULL,
+    applied_at timestamptz NULL,
+    created_at timestamptz NOT NULL,
+    CONSTRAINT salary_changes_pkey PRIMARY KEY (id),
+    CONSTRAINT salary_changes_amount_check CHECK (amount >= 0)
+);
+
+CREATE INDEX salary_changes_employee_id_idx ON salary_changes (employee_id, effective_from DESC, id DESC);
+CREATE INDEX salary_changes_pending_idx ON salary_changes (effective_from) WHERE applied_at IS NULL;
+
+-- Start the history of every employee with its current salary, effective from the day it was created
+INSERT INTO salary_changes (employee_id, amount, effective_from, reason, approved_by, applied_at, created_at)
+SELECT id, salary, (created_at AT TIME ZONE 'Asia/Jakarta')::date, 'initial salary', 'system', now(), now()
+FROM employees;
+
+-- +migrate Down
+DROP TABLE salary_changes;
@@ -0,0 +1,14 @@
+-- +migrate Up notransaction
+CREATE TABLE payroll_profiles (
+    employee_id BIGINT NOT NULL REFERENCES employees (id),
+    ptkp_status text NOT NULL DEFAULT 'TK/0',
+    allowances jsonb NOT NULL DEFAULT '[]',
+    deductions jsonb NOT NULL DEFAULT '[]',
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    CONSTRAINT payroll_profiles_pkey PRIMARY KEY (employee_id),
+    CONSTRAINT payroll_profiles_ptkp_status_check CHECK (ptkp_status IN ('TK/0', 'TK/1', 'TK/2', 'TK/3', 'K/0', 'K/1', 'K/2', 'K/3'))
+);
+
+-- +migrate Down
+DROP TABLE payroll_profiles;
@@ -0,0 +1,88 @@
+-- +migrate Up notransaction
+CREATE TABLE payroll_runs (
+    id BIGSERIAL NOT NULL,
+    period text NOT NULL,
+    status text NOT NULL DEFAULT 'draft',
+    rate_table_version text NOT NULL,
+    employees integer NOT NULL DEFAULT 0,
+    total_gross_pay double precision NOT NULL DEFAULT 0,
+    total_deductions double precision NOT NULL DEFAULT 0,
+    total_pph21 double precision NOT NULL DEFAULT 0,
+    total_net_pay double precision NOT NULL DEFAULT 0,
+    total_employer_contributions double precision NOT NULL DEFAULT 0,
+    created_by text NOT NULL,
+    reviewed_by text,
+    reviewed_at timestamptz,
+    approved_by text,
+    approved_at timestamptz,
+    paid_by text,
+    paid_at timestamptz,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    CONSTRAINT payroll_runs_pkey PRIMARY KEY (id),
+    CONSTRAINT payroll_runs_status_check CHECK (status IN ('draft', 'reviewed', 'approved', 'paid'))
+);
+
+CREATE INDEX payroll_runs_period_idx ON payroll_runs (period, id DESC);
+
+-- only one run of a period may be in progress
+CREATE UNIQUE INDEX payroll_runs_period_in_progress_key ON payroll_runs (period) WHERE status IN ('draft', 'reviewed');
+
+CREATE TABLE payroll_run_items (
+    id BIGSERIAL NOT NULL,
+    payroll_run_id BIGINT NOT NULL REFERENCES payroll_runs (id),
+    employee_id BIGINT NOT NULL REFERENCES employees (id),
+    net_pay double precision NOT NULL,
+    payslip jsonb NOT NULL,
+    created_at timestamptz NOT NULL,
+    CONSTRAINT payroll_run_items_pkey PRIMARY KEY (id),
+    CONSTRAINT payroll_run_items_run_employee_key UNIQUE (payroll_run_id, employee_id)
+);
+
+CREATE INDEX payroll_run_items_employee_id_idx ON payroll_run_items (employee_id);
+
+-- an approved run is read-only, only the approved to paid move is allowed
+-- +migrate StatementBegin
+CREATE FUNCTION payroll_runs_read_only() RETURNS trigger AS $$
+BEGIN
+    IF OLD.status NOT IN ('approved', 'paid') THEN
+        RETURN COALESCE(NEW, OLD);
+    END IF;
+
+    IF TG_OP = 'UPDATE' AND OLD.status = 'approved' AND NEW.status = 'paid' THEN
+        RETURN NEW;
+    END IF;
+
+    RAISE EXCEPTION 'payroll run % is %, it is read-only', OLD.id, OLD.status;
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+CREATE TRIGGER payroll_runs_read_only BEFORE UPDATE OR DELETE ON payroll_runs
+    FOR EACH ROW EXECUTE FUNCTION payroll_runs_read_only();
+
+-- the items are never updated, nor deleted once their run is approved
+-- +migrate StatementBegin
+CREATE FUNCTION payroll_run_items_immutable() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        RAISE EXCEPTION 'payroll run items are immutable';
+    END IF;
+
+    IF EXISTS (SELECT 1 FROM payroll_runs WHERE id = OLD.payroll_run_id AND status IN ('approved', 'paid')) THEN
+        RAISE EXCEPTION 'payroll run % is approved, its items are read-only', OLD.payroll_run_id;
+    END IF;
+
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+CREATE TRIGGER payroll_run_items_immutable BEFORE UPDATE OR DELETE ON payroll_run_items
+    FOR EACH ROW EXECUTE FUNCTION payroll_run_items_immutable();
+
+-- +migrate Down
+DROP TABLE payroll_run_items;
+DROP TABLE payroll_runs;
+DROP FUNCTION payroll_run_items_immutable();
+DROP FUNCTION payroll_runs_read_only();
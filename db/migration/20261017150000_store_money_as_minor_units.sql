@@ -0,0 +1,19 @@
+-- the amounts become BIGINT minor units, the sen of the Rupiah; it runs in a transaction so that
+-- the columns are never left with mixed units
+-- +migrate Up
+ALTER TABLE employees ALTER COLUMN salary TYPE BIGINT USING round(salary * 100)::BIGINT;
+
+ALTER TABLE salary_changes ALTER COLUMN amount TYPE BIGINT USING round(amount * 100)::BIGINT;
+
+ALTER TABLE positions
+    ALTER COLUMN min_salary TYPE BIGINT USING round(min_salary * 100)::BIGINT,
+    ALTER COLUMN max_salary TYPE BIGINT USING round(max_salary * 100)::BIGINT;
+
+-- +migrate Down
+ALTER TABLE positions
+    ALTER COLUMN min_salary TYPE float8 USING min_salary / 100.0,
+    ALTER COLUMN max_salary TYPE float8 USING max_salary / 100.0;
+
+ALTER TABLE salary_changes ALTER COLUMN amount TYPE float8 USING amount / 100.0;
+
+ALTER TABLE employees ALTER COLUMN salary TYPE float8 USING salary / 100.0;
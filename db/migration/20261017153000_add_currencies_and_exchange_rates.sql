@@ -0,0 +1,25 @@
+-- +migrate Up notransaction
+-- Every salary so far is in Rupiah
+ALTER TABLE employees ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'IDR';
+ALTER TABLE salary_changes ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'IDR';
+
+-- The rate is the amount of Rupiah of one unit of the currency, effective from the day until the next rate
+CREATE TABLE exchange_rates (
+    id BIGSERIAL NOT NULL,
+    currency CHAR(3) NOT NULL,
+    rate NUMERIC(20, 8) NOT NULL,
+    effective_from date NOT NULL,
+    created_by text NOT NULL,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    CONSTRAINT exchange_rates_pkey PRIMARY KEY (id),
+    CONSTRAINT exchange_rates_rate_check CHECK (rate > 0),
+    CONSTRAINT exchange_rates_currency_check CHECK (currency <> 'IDR')
+);
+
+CREATE UNIQUE INDEX exchange_rates_currency_effective_from_uniq_idx ON exchange_rates (currency, effective_from);
+
+-- +migrate Down
+DROP TABLE exchange_rates;
+ALTER TABLE salary_changes DROP COLUMN currency;
+ALTER TABLE employees DROP COLUMN currency;
@@ -0,0 +1,95 @@
+-- the payroll amounts become BIGINT minor units like the salaries, and the amounts of the payslip snapshots and the
+-- payroll profiles are rewritten as Money, a decimal string of Rupiah; it runs in a transaction so that the
+-- amounts are never left with mixed units
+-- +migrate Up
+ALTER TABLE payroll_runs
+    ALTER COLUMN total_gross_pay TYPE BIGINT USING round(total_gross_pay * 100)::BIGINT,
+    ALTER COLUMN total_deductions TYPE BIGINT USING round(total_deductions * 100)::BIGINT,
+    ALTER COLUMN total_pph21 TYPE BIGINT USING round(total_pph21 * 100)::BIGINT,
+    ALTER COLUMN total_net_pay TYPE BIGINT USING round(total_net_pay * 100)::BIGINT,
+    ALTER COLUMN total_employer_contributions TYPE BIGINT USING round(total_employer_contributions * 100)::BIGINT;
+
+ALTER TABLE payroll_run_items ALTER COLUMN net_pay TYPE BIGINT USING round(net_pay * 100)::BIGINT;
+
+-- +migrate StatementBegin
+CREATE FUNCTION pg_temp.money_json(amount jsonb) RETURNS jsonb AS $$
+    SELECT CASE WHEN jsonb_typeof(amount) = 'number' THEN to_jsonb(round((amount #>> '{}')::numeric, 2)::text) ELSE amount END
+$$ LANGUAGE sql IMMUTABLE;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE FUNCTION pg_temp.money_lines_json(lines jsonb) RETURNS jsonb AS $$
+    SELECT CASE WHEN jsonb_typeof(lines) = 'array' THEN (
+        SELECT COALESCE(jsonb_agg(line || jsonb_build_object('amount', pg_temp.money_json(line -> 'amount')) ORDER BY idx), '[]'::jsonb)
+        FROM jsonb_array_elements(lines) WITH ORDINALITY AS l(line, idx)
+    ) ELSE lines END
+$$ LANGUAGE sql IMMUTABLE;
+-- +migrate StatementEnd
+
+-- the snapshots are immutable, the trigger is only lifted while their amounts are rewritten
+ALTER TABLE payroll_run_items DISABLE TRIGGER payroll_run_items_immutable;
+
+UPDATE payroll_run_items SET payslip = payslip || jsonb_build_object(
+    'base_salary', pg_temp.money_json(payslip -> 'base_salary'),
+    'gross_pay', pg_temp.money_json(payslip -> 'gross_pay'),
+    'total_deductions', pg_temp.money_json(payslip -> 'total_deductions'),
+    'total_employer_contributions', pg_temp.money_json(payslip -> 'total_employer_contributions'),
+    'taxable_income', pg_temp.money_json(payslip -> 'taxable_income'),
+    'pph21', pg_temp.money_json(payslip -> 'pph21'),
+    'net_pay', pg_temp.money_json(payslip -> 'net_pay'),
+    'allowances', pg_temp.money_lines_json(payslip -> 'allowances'),
+    'deductions', pg_temp.money_lines_json(payslip -> 'deductions'),
+    'employer_contributions', pg_temp.money_lines_json(payslip -> 'employer_contributions')
+);
+
+ALTER TABLE payroll_run_items ENABLE TRIGGER payroll_run_items_immutable;
+
+UPDATE payroll_profiles SET
+    allowances = pg_temp.money_lines_json(allowances),
+    deductions = pg_temp.money_lines_json(deductions);
+
+-- +migrate Down
+-- +migrate StatementBegin
+CREATE FUNCTION pg_temp.money_json(amount jsonb) RETURNS jsonb AS $$
+    SELECT CASE WHEN jsonb_typeof(amount) = 'string' THEN to_jsonb((amount #>> '{}')::numeric) ELSE amount END
+$$ LANGUAGE sql IMMUTABLE;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE FUNCTION pg_temp.money_lines_json(lines jsonb) RETURNS jsonb AS $$
+    SELECT CASE WHEN jsonb_typeof(lines) = 'array' THEN (
+        SELECT COALESCE(jsonb_agg(line || jsonb_build_object('amount', pg_temp.money_json(line -> 'amount')) ORDER BY idx), '[]'::jsonb)
+        FROM jsonb_array_elements(lines) WITH ORDINALITY AS l(line, idx)
+    ) ELSE lines END
+$$ LANGUAGE sql IMMUTABLE;
+-- +migrate StatementEnd
+
+UPDATE payroll_profiles SET
+    allowances = pg_temp.money_lines_json(allowances),
+    deductions = pg_temp.money_lines_json(deductions);
+
+ALTER TABLE payroll_run_items DISABLE TRIGGER payroll_run_items_immutable;
+
+UPDATE payroll_run_items SET payslip = payslip || jsonb_build_object(
+    'base_salary', pg_temp.money_json(payslip -> 'base_salary'),
+    'gross_pay', pg_temp.money_json(payslip -> 'gross_pay'),
+    'total_deductions', pg_temp.money_json(payslip -> 'total_deductions'),
+    'total_employer_contributions', pg_temp.money_json(payslip -> 'total_employer_contributions'),
+    'taxable_income', pg_temp.money_json(payslip -> 'taxable_income'),
+    'pph21', pg_temp.money_json(payslip -> 'pph21'),
+    'net_pay', pg_temp.money_json(payslip -> 'net_pay'),
+    'allowances', pg_temp.money_lines_json(payslip -> 'allowances'),
+    'deductions', pg_temp.money_lines_json(payslip -> 'deductions'),
+    'employer_contributions', pg_temp.money_lines_json(payslip -> 'employer_contributions')
+);
+
+ALTER TABLE payroll_run_items ENABLE TRIGGER payroll_run_items_immutable;
+
+ALTER TABLE payroll_run_items ALTER COLUMN net_pay TYPE double precision USING net_pay / 100.0;
+
+ALTER TABLE payroll_runs
+    ALTER COLUMN total_gross_pay TYPE double precision USING total_gross_pay / 100.0,
+    ALTER COLUMN total_deductions TYPE double precision USING total_deductions / 100.0,
+    ALTER COLUMN total_pph21 TYPE double precision USING total_pph21 / 100.0,
+    ALTER COLUMN total_net_pay TYPE double precision USING total_net_pay / 100.0,
+    ALTER COLUMN total_employer_contributions TYPE double precision USING total_employer_contributions / 100.0;
@@ -0,0 +1,8 @@
+-- a period has a single run whatever its status, an approved or paid period is never run, nor paid, twice
+-- +migrate Up
+DROP INDEX payroll_runs_period_in_progress_key;
+CREATE UNIQUE INDEX payroll_runs_period_key ON payroll_runs (period);
+
+-- +migrate Down
+DROP INDEX payroll_runs_period_key;
+CREATE UNIQUE INDEX payroll_runs_period_in_progress_key ON payroll_runs (period) WHERE status IN ('draft', 'reviewed');
@@ -0,0 +1,81 @@
+-- The seed of the catalog suffixed the colliding codes without checking the suffixed ones, and skipped the employees
+-- without a position, which then couldn't be updated without changing it. Backfill every held position missing from
+-- the catalog and place the employees without a position on an Unassigned position. The bands are in Rupiah, a
+-- salary in another currency is converted at the rate of the day, as is when no rate is effective yet.
+-- +migrate Up
+-- +migrate StatementBegin
+DO $$
+DECLARE
+    held record;
+    candidate text;
+    suffix integer;
+BEGIN
+    CREATE TEMPORARY TABLE held_salaries ON COMMIT DROP AS
+    SELECT
+        trim(e.position) AS position,
+        round(e.salary * coalesce((
+            SELECT r.rate FROM exchange_rates r
+            WHERE r.currency = e.currency AND r.effective_from <= current_date
+            ORDER BY r.effective_from DESC
+            LIMIT 1
+        ), 1))::BIGINT AS salary
+    FROM employees e
+    WHERE e.deleted_at IS NULL;
+
+    IF EXISTS (SELECT 1 FROM held_salaries WHERE position = '')
+        AND NOT EXISTS (SELECT 1 FROM positions WHERE lower(title) = 'unassigned' AND deleted_at IS NULL) THEN
+        candidate := 'UNASSIGNED';
+        suffix := 1;
+        WHILE EXISTS (SELECT 1 FROM positions WHERE upper(code) = candidate AND deleted_at IS NULL) LOOP
+            suffix := suffix + 1;
+            candidate := 'UNASSIGNED_' || suffix;
+        END LOOP;
+
+        INSERT INTO positions (code, title, level, min_salary, max_salary, created_at, updated_at)
+        SELECT candidate, 'Unassigned', 1, 0, GREATEST(max(salary), 0), now(), now()
+        FROM held_salaries
+        WHERE position = '';
+    END IF;
+
+    -- the first title of each code gets it as is, the next ones get the first _2, _3... suffix which isn't taken
+    FOR held IN
+        SELECT title, base_code, min_salary, max_salary
+        FROM (
+            SELECT
+                min(position) AS title,
+                coalesce(nullif(upper(trim(BOTH '_' FROM regexp_replace(min(position), '[^a-zA-Z0-9]+', '_', 'g'))), ''), 'POSITION') AS base_code,
+                min(salary) AS min_salary,
+                max(salary) AS max_salary
+            FROM held_salaries
+            WHERE position <> ''
+            GROUP BY lower(position)
+        ) titles
+        WHERE NOT EXISTS (SELECT 1 FROM positions WHERE lower(positions.title) = lower(titles.title) AND deleted_at IS NULL)
+        ORDER BY row_number() OVER (PARTITION BY base_code ORDER BY title), title
+    LOOP
+        candidate := held.base_code;
+        suffix := 1;
+        WHILE EXISTS (SELECT 1 FROM positions WHERE upper(code) = candidate AND deleted_at IS NULL) LOOP
+            suffix := suffix + 1;
+            candidate := held.base_code || '_' || suffix;
+        END LOOP;
+
+        INSERT INTO positions (code, title, level, min_salary, max_salary, created_at, updated_at)
+        VALUES (candidate, held.title, 1, GREATEST(held.min_salary, 0), GREATEST(held.max_salary, 0), now(), now());
+    END LOOP;
+END;
+$$;
+-- +migrate StatementEnd
+
+UPDATE employees SET position = positions.title
+FROM positions
+WHERE trim(employees.position) = '' AND lower(positions.title) = 'unassigned' AND positions.deleted_at IS NULL;
+
+-- Align the employees on the catalog titles
+UPDATE employees SET position = positions.title
+FROM positions
+WHERE lower(trim(employees.position)) = lower(positions.title) AND positions.deleted_at IS NULL
+    AND employees.position <> positions.title;
+
+-- +migrate Down
+-- The backfilled positions are kept, the employees hold them
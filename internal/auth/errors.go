@@ -0,0 +1,11 @@
+package auth
+
+import "errors"
+
+var (
+	ErrMissingToken         = errors.New("missing token")
+	ErrInvalidToken         = errors.New("invalid token")
+	ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+	ErrMissingSigningKey    = errors.New("missing signing key")
+	ErrInvalidAuthorization = errors.New("invalid authorization header")
+)
@@ -0,0 +1,135 @@
+package auth
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+)
+
+// Claims is the JWT claims accepted by the service
+type Claims struct {
+	jwt.RegisteredClaims
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+	Role  string `json:"role,omitempty"`
+	// EmployeeID the employee the user is, when the user is one
+	EmployeeID int64 `json:"employee_id,omitempty"`
+}
+
+// TokenVerifier verifies a bearer token and returns the authenticated user
+type TokenVerifier interface {
+	Verify(tokenString string) (*User, error)
+}
+
+// JWTOptions options for the JWT verifier
+type JWTOptions struct {
+	Algorithm     string
+	Secret        string
+	PublicKeyFile string
+	Issuer        string
+	Audience      string
+	Leeway        time.Duration
+}
+
+type jwtVerifier struct {
+	algorithm string
+	key       any
+	parser    *jwt.Parser
+}
+
+// NewJWTVerifier create a TokenVerifier for HS256 (shared secret) or RS256 (public key file) tokens
+func NewJWTVerifier(opts JWTOptions) (TokenVerifier, error) {
+	algorithm := strings.ToUpper(opts.Algorithm)
+
+	var key any
+	switch algorithm {
+	case AlgorithmHS256:
+		if opts.Secret == "" {
+			return nil, ErrMissingSigningKey
+		}
+		key = []byte(opts.Secret)
+	case AlgorithmRS256:
+		if opts.PublicKeyFile == "" {
+			return nil, ErrMissingSigningKey
+		}
+
+		pem, err := os.ReadFile(opts.PublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err = jwt.ParseRSAPublicKeyFromPEM(pem)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+
+	parserOpts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{algorithm}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(opts.Leeway),
+	}
+	if opts.Issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(opts.Issuer))
+	}
+	if opts.Audience != "" {
+		parserOpts = append(parserOpts, jwt.WithAudience(opts.Audience))
+	}
+
+	return &jwtVerifier{
+		algorithm: algorithm,
+		key:       key,
+		parser:    jwt.NewParser(parserOpts...),
+	}, nil
+}
+
+// Verify parse and validate the token signature and registered claims
+func (v *jwtVerifier) Verify(tokenString string) (*User, error) {
+	if tokenString == "" {
+		return nil, ErrMissingToken
+	}
+
+	claims := &Claims{}
+	token, err := v.parser.ParseWithClaims(tokenString, claims, func(_ *jwt.Token) (any, error) {
+		return v.key, nil
+	})
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	if claims.Subject == "" {
+		return nil, ErrInvalidToken
+	}
+
+	return &User{
+		ID:         claims.Subject,
+		Name:       claims.Name,
+		Email:      claims.Email,
+		Role:       claims.Role,
+		EmployeeID: claims.EmployeeID,
+		Claims:     claims,
+	}, nil
+}
+
+// ParseBearerToken extract the token from an "Authorization: Bearer <token>" header value
+func ParseBearerToken(authorization string) (string, error) {
+	if authorization == "" {
+		return "", ErrMissingToken
+	}
+
+	scheme, token, ok := strings.Cut(authorization, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return "", ErrInvalidAuthorization
+	}
+
+	return strings.TrimSpace(token), nil
+}
@@ -0,0 +1,124 @@
+package auth
+
+// Role of the authenticated user
+type Role string
+
+// Permission is an action that can be granted to a role
+type Permission string
+
+const (
+	RoleAdmin   Role = "admin"
+	RoleHR      Role = "hr"
+	RoleManager Role = "manager"
+	RoleViewer  Role = "viewer"
+)
+
+const (
+	PermissionEmployeeRead   Permission = "employee:read"
+	PermissionEmployeeCreate Permission = "employee:create"
+	PermissionEmployeeUpdate Permission = "employee:update"
+	PermissionEmployeeDelete Permission = "employee:delete"
+
+	PermissionEmployeeSalaryRead Permission = "employee:salary:read"
+	PermissionEmployeeAuditRead  Permission = "employee:audit:read"
+	// PermissionEmployeeSalaryChange allows recording a salary change, the user is recorded as its approver
+	PermissionEmployeeSalaryChange Permission = "employee:salary:change"
+
+	PermissionDepartmentRead   Permission = "department:read"
+	PermissionDepartmentCreate Permission = "department:create"
+	PermissionDepartmentUpdate Permission = "department:update"
+	PermissionDepartmentDelete Permission = "department:delete"
+
+	PermissionPositionRead   Permission = "position:read"
+	PermissionPositionCreate Permission = "position:create"
+	PermissionPositionUpdate Permission = "position:update"
+	PermissionPositionDelete Permission = "position:delete"
+
+	PermissionPayrollRead          Permission = "payroll:read"
+	PermissionPayrollRun           Permission = "payroll:run"
+	PermissionPayrollProfileUpdate Permission = "payroll:profile:update"
+	// PermissionPayrollApprove allows approving a reviewed payroll run and recording its payment
+	PermissionPayrollApprove Permission = "payroll:approve"
+
+	// PermissionEmployeeSalaryBandOverride allows a salary out of the band of the position
+	PermissionEmployeeSalaryBandOverride Permission = "employee:salary_band:override"
+
+	PermissionExchangeRateRead Permission = "exchange_rate:read"
+	// PermissionExchangeRateManage allows recording, importing and deleting the exchange rates
+	PermissionExchangeRateManage Permission = "exchange_rate:manage"
+)
+
+// rolePermissions maps each role to the permissions granted to it,
+// a role which is not listed here has no permission at all
+var rolePermissions = map[Role][]Permission{
+	RoleAdmin: {
+		PermissionEmployeeRead,
+		PermissionEmployeeCreate,
+		PermissionEmployeeUpdate,
+		PermissionEmployeeDelete,
+		PermissionEmployeeSalaryRead,
+		PermissionEmployeeAuditRead,
+		PermissionEmployeeSalaryChange,
+		PermissionDepartmentRead,
+		PermissionDepartmentCreate,
+		PermissionDepartmentUpdate,
+		PermissionDepartmentDelete,
+		PermissionPositionRead,
+		PermissionPositionCreate,
+		PermissionPositionUpdate,
+		PermissionPositionDelete,
+		PermissionPayrollRead,
+		PermissionPayrollRun,
+		PermissionPayrollProfileUpdate,
+		PermissionPayrollApprove,
+		PermissionEmployeeSalaryBandOverride,
+		PermissionExchangeRateRead,
+		PermissionExchangeRateManage,
+	},
+	RoleHR: {
+		PermissionEmployeeRead,
+		PermissionEmployeeCreate,
+		PermissionEmployeeUpdate,
+		PermissionEmployeeDelete,
+		PermissionEmployeeSalaryRead,
+		PermissionEmployeeAuditRead,
+		PermissionEmployeeSalaryChange,
+		PermissionDepartmentRead,
+		PermissionDepartmentCreate,
+		PermissionDepartmentUpdate,
+		PermissionDepartmentDelete,
+		PermissionPositionRead,
+		PermissionPositionCreate,
+		PermissionPositionUpdate,
+		PermissionPositionDelete,
+		PermissionPayrollRead,
+		PermissionPayrollRun,
+		PermissionPayrollProfileUpdate,
+		PermissionExchangeRateRead,
+	},
+	RoleManager: {
+		PermissionEmployeeRead,
+		PermissionDepartmentRead,
+		PermissionPositionRead,
+	},
+	RoleViewer: {
+		PermissionEmployeeRead,
+		PermissionDepartmentRead,
+		PermissionPositionRead,
+	},
+}
+
+// HasPermission check whether the user's role is granted the permission, a nil user has no permission
+func (u *User) HasPermission(permission Permission) bool {
+	if u == nil {
+		return false
+	}
+
+	for _, p := range rolePermissions[Role(u.Role)] {
+		if p == permission {
+			return true
+		}
+	}
+
+	return false
+}
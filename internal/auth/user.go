@@ -0,0 +1,39 @@
+package auth
+
+import "context"
+
+type contextKey string
+
+// userCtxKey is the context key of the authenticated user
+const userCtxKey contextKey = "auth:user"
+
+// User is the authenticated caller extracted from a verified token
+type User struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Role  string `json:"role"`
+	// EmployeeID the employee the user is, zero when the user isn't one
+	EmployeeID int64   `json:"employee_id,omitempty"`
+	Claims     *Claims `json:"-"`
+}
+
+// IsEmployee check whether the user is the employee, a nil user is no employee
+func (u *User) IsEmployee(employeeID int64) bool {
+	return u != nil && u.EmployeeID > 0 && u.EmployeeID == employeeID
+}
+
+// SetUserToCtx set the authenticated user to the context
+func SetUserToCtx(ctx context.Context, user *User) context.Context {
+	return context.WithValue(ctx, userCtxKey, user)
+}
+
+// GetUserFromCtx get the authenticated user from the context, return nil if not found
+func GetUserFromCtx(ctx context.Context) *User {
+	user, ok := ctx.Value(userCtxKey).(*User)
+	if !ok {
+		return nil
+	}
+
+	return user
+}
@@ -193,6 +193,56 @@ func CacheTTL() time.Duration {
 	return parseDuration(cfg, DefaultRedisCacheTTL)
 }
 
+// SuggestCacheTTL :nodoc:
+func SuggestCacheTTL() time.Duration {
+	cfg := viper.GetString("suggest_cache_ttl")
+	return parseDuration(cfg, DefaultSuggestCacheTTL)
+}
+
+// OrgChartCacheTTL :nodoc:
+func OrgChartCacheTTL() time.Duration {
+	cfg := viper.GetString("org_chart_cache_ttl")
+	return parseDuration(cfg, DefaultOrgChartCacheTTL)
+}
+
+// SalaryChangeApplyInterval :nodoc:
+func SalaryChangeApplyInterval() time.Duration {
+	cfg := viper.GetString("salary_change_apply_interval")
+	return parseDuration(cfg, DefaultSalaryChangeApplyInterval)
+}
+
+// PayrollRateTablesFile :nodoc:
+func PayrollRateTablesFile() string {
+	if viper.GetString("payroll.rate_tables_file") != "" {
+		return viper.GetString("payroll.rate_tables_file")
+	}
+	return DefaultPayrollRateTablesFile
+}
+
+// PayslipCompanyName :nodoc:
+func PayslipCompanyName() string {
+	if viper.GetString("payroll.payslip_company_name") != "" {
+		return viper.GetString("payroll.payslip_company_name")
+	}
+	return DefaultPayslipCompanyName
+}
+
+// MoneyJSONFormat :nodoc:
+func MoneyJSONFormat() string {
+	if viper.GetString("money_json_format") != "" {
+		return viper.GetString("money_json_format")
+	}
+	return DefaultMoneyJSONFormat
+}
+
+// ReportingCurrency :nodoc:
+func ReportingCurrency() string {
+	if viper.GetString("reporting_currency") != "" {
+		return viper.GetString("reporting_currency")
+	}
+	return DefaultReportingCurrency
+}
+
 func parseDuration(in string, defaultDuration time.Duration) time.Duration {
 	dur, err := time.ParseDuration(in)
 	if err != nil {
@@ -200,3 +250,69 @@ func parseDuration(in string, defaultDuration time.Duration) time.Duration {
 	}
 	return dur
 }
+
+// BulkCreateMaxItems :nodoc:
+func BulkCreateMaxItems() int {
+	if viper.GetInt("bulk_create_max_items") > 0 {
+		return viper.GetInt("bulk_create_max_items")
+	}
+	return DefaultBulkCreateMaxItems
+}
+
+// ExportBatchSize :nodoc:
+func ExportBatchSize() int {
+	if viper.GetInt("export_batch_size") > 0 {
+		return viper.GetInt("export_batch_size")
+	}
+	return DefaultExportBatchSize
+}
+
+// SearchMaxSize :nodoc:
+func SearchMaxSize() int64 {
+	if viper.GetInt64("search_max_size") > 0 {
+		return viper.GetInt64("search_max_size")
+	}
+	return DefaultSearchMaxSize
+}
+
+// OrgChartMaxDepth :nodoc:
+func OrgChartMaxDepth() int {
+	if viper.GetInt("org_chart_max_depth") > 0 {
+		return viper.GetInt("org_chart_max_depth")
+	}
+	return DefaultOrgChartMaxDepth
+}
+
+// JWTAlgorithm :nodoc:
+func JWTAlgorithm() string {
+	if viper.IsSet("auth.jwt.algorithm") {
+		return viper.GetString("auth.jwt.algorithm")
+	}
+	return DefaultJWTAlgorithm
+}
+
+// JWTSecret :nodoc:
+func JWTSecret() string {
+	return viper.GetString("auth.jwt.secret")
+}
+
+// JWTPublicKeyFile :nodoc:
+func JWTPublicKeyFile() string {
+	return viper.GetString("auth.jwt.public_key_file")
+}
+
+// JWTIssuer :nodoc:
+func JWTIssuer() string {
+	return viper.GetString("auth.jwt.issuer")
+}
+
+// JWTAudience :nodoc:
+func JWTAudience() string {
+	return viper.GetString("auth.jwt.audience")
+}
+
+// JWTLeeway :nodoc:
+func JWTLeeway() time.Duration {
+	cfg := viper.GetString("auth.jwt.leeway")
+	return parseDuration(cfg, DefaultJWTLeeway)
+}
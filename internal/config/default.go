@@ -10,5 +10,21 @@ const (
 	DefaultDatabaseRetryAttempts   = 3
 	DefaultDatabaseTimeout         = 120
 
-	DefaultRedisCacheTTL = 15 * time.Minute
+	DefaultRedisCacheTTL    = 15 * time.Minute
+	DefaultSuggestCacheTTL  = 1 * time.Minute
+	DefaultOrgChartCacheTTL = 5 * time.Minute
+
+	DefaultBulkCreateMaxItems = 100
+	DefaultExportBatchSize    = 500
+	DefaultSearchMaxSize      = 100
+	DefaultOrgChartMaxDepth   = 10
+
+	DefaultSalaryChangeApplyInterval = 1 * time.Hour
+	DefaultPayrollRateTablesFile     = "payroll_rates.yml"
+	DefaultPayslipCompanyName        = "Employee API"
+	DefaultMoneyJSONFormat           = "number"
+	DefaultReportingCurrency         = "IDR"
+
+	DefaultJWTAlgorithm = "HS256"
+	DefaultJWTLeeway    = 30 * time.Second
 )
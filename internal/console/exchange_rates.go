@@ -0,0 +1,63 @@
+package console
+
+import (
+	"context"
+	"github.com/irvankadhafi/employee-api/internal/auth"
+	"github.com/irvankadhafi/employee-api/internal/db"
+	"github.com/irvankadhafi/employee-api/internal/helper"
+	"github.com/irvankadhafi/employee-api/internal/repository"
+	"github.com/irvankadhafi/employee-api/internal/usecase"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+var exchangeRatesCmd = &cobra.Command{
+	Use:   "exchange-rates [file]",
+	Short: "load exchange rates from a CSV file",
+	Long:  `This subcommand record the exchange rates of a CSV file with the currency, rate and optional effective_from columns, no rate is saved when a row is rejected`,
+	Args:  cobra.ExactArgs(1),
+	Run:   runExchangeRates,
+}
+
+func init() {
+	exchangeRatesCmd.PersistentFlags().String("actor", "console", "actor recorded on the rates")
+	RootCmd.AddCommand(exchangeRatesCmd)
+}
+
+func runExchangeRates(cmd *cobra.Command, args []string) {
+	actor, err := cmd.Flags().GetString("actor")
+	continueOrFatal(err)
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		log.WithField("file", args[0]).Fatal("Failed to open file: ", err)
+	}
+	defer helper.WrapCloser(file.Close)
+
+	db.InitializePostgresConn()
+	pgDB, err := db.PostgreSQL.DB()
+	continueOrFatal(err)
+	defer helper.WrapCloser(pgDB.Close)
+
+	exchangeRateUsecase := usecase.NewExchangeRateUsecase(repository.NewExchangeRateRepository(db.PostgreSQL))
+
+	// the console is trusted, it acts as an admin under the given actor name
+	ctx := auth.SetUserToCtx(context.Background(), &auth.User{
+		ID:   actor,
+		Name: actor,
+		Role: string(auth.RoleAdmin),
+	})
+
+	result, err := exchangeRateUsecase.Import(ctx, file)
+	if result != nil {
+		for _, rowErr := range result.Errors {
+			log.WithField("line", rowErr.Line).Warn(rowErr.Errors)
+		}
+	}
+	if err != nil {
+		log.WithField("file", args[0]).Fatal("Failed to load exchange rates: ", err)
+	}
+
+	log.Infof("Loaded %d rows: %d imported", result.Total, result.Imported)
+}
@@ -0,0 +1,94 @@
+package console
+
+import (
+	"context"
+	"github.com/irvankadhafi/employee-api/internal/auth"
+	"github.com/irvankadhafi/employee-api/internal/db"
+	"github.com/irvankadhafi/employee-api/internal/helper"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/irvankadhafi/employee-api/internal/repository"
+	"github.com/irvankadhafi/employee-api/internal/usecase"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "import employees from a CSV file",
+	Long:  `This subcommand create or upsert the employees of a CSV file with the name, position, salary and optional external_key columns`,
+	Args:  cobra.ExactArgs(1),
+	Run:   runImport,
+}
+
+func init() {
+	importCmd.PersistentFlags().Bool("dry-run", false, "validate the file without saving")
+	importCmd.PersistentFlags().Bool("upsert", false, "update the employee of an existing external_key")
+	importCmd.PersistentFlags().String("error-report", "", "path of the CSV row error report")
+	importCmd.PersistentFlags().String("actor", "console", "actor recorded in the audit trail")
+	RootCmd.AddCommand(importCmd)
+}
+
+func runImport(cmd *cobra.Command, args []string) {
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	continueOrFatal(err)
+	upsert, err := cmd.Flags().GetBool("upsert")
+	continueOrFatal(err)
+	errorReportPath, err := cmd.Flags().GetString("error-report")
+	continueOrFatal(err)
+	actor, err := cmd.Flags().GetString("actor")
+	continueOrFatal(err)
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		log.WithField("file", args[0]).Fatal("Failed to open file: ", err)
+	}
+	defer helper.WrapCloser(file.Close)
+
+	db.InitializePostgresConn()
+	pgDB, err := db.PostgreSQL.DB()
+	continueOrFatal(err)
+	defer helper.WrapCloser(pgDB.Close)
+
+	cacheManager, closeCacheManager := newCacheManager()
+	defer closeCacheManager()
+
+	employeeRepository := repository.NewEmployeeRepository(db.PostgreSQL, cacheManager)
+	departmentRepository := repository.NewDepartmentRepository(db.PostgreSQL, cacheManager)
+	positionRepository := repository.NewPositionRepository(db.PostgreSQL, cacheManager)
+	exchangeRateRepository := repository.NewExchangeRateRepository(db.PostgreSQL)
+	employeeUsecase := usecase.NewEmployeeUsecase(employeeRepository, departmentRepository, positionRepository, exchangeRateRepository)
+
+	// the console is trusted, it acts as an admin under the given actor name
+	ctx := auth.SetUserToCtx(context.Background(), &auth.User{
+		ID:   actor,
+		Name: actor,
+		Role: string(auth.RoleAdmin),
+	})
+
+	result, err := employeeUsecase.Import(ctx, file, model.EmployeeImportOptions{
+		DryRun: dryRun,
+		Upsert: upsert,
+	})
+	if err != nil {
+		log.WithField("file", args[0]).Fatal("Failed to import employees: ", err)
+	}
+
+	if errorReportPath != "" && len(result.Errors) > 0 {
+		report, err := os.Create(errorReportPath)
+		continueOrFatal(err)
+		defer helper.WrapCloser(report.Close)
+
+		continueOrFatal(result.WriteErrorReport(report))
+	}
+
+	for _, rowErr := range result.Errors {
+		log.WithFields(log.Fields{
+			"line":         rowErr.Line,
+			"external_key": rowErr.ExternalKey,
+		}).Warn(rowErr.Errors)
+	}
+
+	log.Infof("Imported %d rows: %d created, %d updated, %d rejected (dry run: %t)",
+		result.Total, result.Created, result.Updated, result.Rejected, result.DryRun)
+}
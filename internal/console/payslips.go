@@ -0,0 +1,96 @@
+package console
+
+import (
+	"context"
+	"github.com/irvankadhafi/employee-api/internal/auth"
+	"github.com/irvankadhafi/employee-api/internal/config"
+	"github.com/irvankadhafi/employee-api/internal/db"
+	"github.com/irvankadhafi/employee-api/internal/helper"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/irvankadhafi/employee-api/internal/payroll"
+	"github.com/irvankadhafi/employee-api/internal/repository"
+	"github.com/irvankadhafi/employee-api/internal/usecase"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"os"
+	"path/filepath"
+)
+
+var payslipsCmd = &cobra.Command{
+	Use:   "payslips [period]",
+	Short: "write the payslip PDFs of a period",
+	Long:  `This subcommand write the payslip PDF of every employee for a YYYY-MM period into a directory, from the approved payroll run of the period when there is one`,
+	Args:  cobra.ExactArgs(1),
+	Run:   runPayslips,
+}
+
+func init() {
+	payslipsCmd.PersistentFlags().String("dir", ".", "directory the PDFs are written into, created when missing")
+	payslipsCmd.PersistentFlags().String("actor", "console", "actor reading the payroll")
+	RootCmd.AddCommand(payslipsCmd)
+}
+
+func runPayslips(cmd *cobra.Command, args []string) {
+	dir, err := cmd.Flags().GetString("dir")
+	continueOrFatal(err)
+	actor, err := cmd.Flags().GetString("actor")
+	continueOrFatal(err)
+	period := args[0]
+
+	continueOrFatal(os.MkdirAll(dir, 0o755))
+
+	db.InitializePostgresConn()
+	pgDB, err := db.PostgreSQL.DB()
+	continueOrFatal(err)
+	defer helper.WrapCloser(pgDB.Close)
+
+	cacheManager, closeCacheManager := newCacheManager()
+	defer closeCacheManager()
+
+	rateTables, err := payroll.LoadRateTables(config.PayrollRateTablesFile())
+	continueOrFatal(err)
+
+	employeeRepository := repository.NewEmployeeRepository(db.PostgreSQL, cacheManager)
+	payrollRepository := repository.NewPayrollRepository(db.PostgreSQL, cacheManager)
+	exchangeRateRepository := repository.NewExchangeRateRepository(db.PostgreSQL)
+	payrollUsecase := usecase.NewPayrollUsecase(employeeRepository, payrollRepository, exchangeRateRepository, rateTables)
+
+	// the console is trusted, it acts as an admin under the given actor name
+	ctx := auth.SetUserToCtx(context.Background(), &auth.User{
+		ID:   actor,
+		Name: actor,
+		Role: string(auth.RoleAdmin),
+	})
+
+	companyName := config.PayslipCompanyName()
+	written := 0
+	err = payrollUsecase.ExportPayslips(ctx, period, func(payslips []*model.Payslip) error {
+		for _, payslip := range payslips {
+			if err := writePayslipFile(filepath.Join(dir, payroll.PayslipFileName(payslip)), payslip, companyName); err != nil {
+				return err
+			}
+			written++
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.WithField("period", period).Fatal("Failed to write the payslips: ", err)
+	}
+
+	log.Infof("Wrote %d payslips of %s into %s", written, period, dir)
+}
+
+func writePayslipFile(path string, payslip *model.Payslip, companyName string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := payroll.WritePayslipPDF(file, payslip, companyName); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
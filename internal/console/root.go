@@ -4,6 +4,7 @@ import (
 	runtime "github.com/banzaicloud/logrus-runtime-formatter"
 	"github.com/irvankadhafi/employee-api/internal/config"
 	"github.com/irvankadhafi/employee-api/internal/db"
+	"github.com/irvankadhafi/employee-api/internal/model"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"os"
@@ -31,6 +32,7 @@ func Execute() {
 func init() {
 	config.GetConf()
 	setupLogger()
+	model.SetMoneyJSONFormat(model.MoneyJSONFormat(config.MoneyJSONFormat()))
 
 	redisOpts = &db.RedisConnectionPoolOptions{
 		DialTimeout:     config.RedisDialTimeout(),
@@ -4,10 +4,14 @@ import (
 	"context"
 	"fmt"
 	"github.com/irvankadhafi/employee-api/cacher"
+	"github.com/irvankadhafi/employee-api/internal/auth"
 	"github.com/irvankadhafi/employee-api/internal/config"
 	"github.com/irvankadhafi/employee-api/internal/db"
+	grpcsvc "github.com/irvankadhafi/employee-api/internal/delivery/grpc"
 	httpsvc "github.com/irvankadhafi/employee-api/internal/delivery/http"
 	"github.com/irvankadhafi/employee-api/internal/helper"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/irvankadhafi/employee-api/internal/payroll"
 	"github.com/irvankadhafi/employee-api/internal/repository"
 	"github.com/irvankadhafi/employee-api/internal/usecase"
 	"github.com/labstack/echo/v4"
@@ -15,6 +19,8 @@ import (
 	"github.com/labstack/gommon/log"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -40,23 +46,8 @@ func runServer(cmd *cobra.Command, args []string) {
 	continueOrFatal(err)
 	defer helper.WrapCloser(pgDB.Close)
 
-	cacheManager := cacher.NewCacheManager()
-
-	cacheManager.SetDisableCaching(config.DisableCaching())
-
-	if !config.DisableCaching() {
-		redisConn, err := db.NewRedigoRedisConnectionPool(config.RedisCacheHost(), redisOpts)
-		continueOrFatal(err)
-		defer helper.WrapCloser(redisConn.Close)
-
-		redisLockConn, err := db.NewRedigoRedisConnectionPool(config.RedisLockHost(), redisOpts)
-		continueOrFatal(err)
-		defer helper.WrapCloser(redisLockConn.Close)
-
-		cacheManager.SetConnectionPool(redisConn)
-		cacheManager.SetLockConnectionPool(redisLockConn)
-		cacheManager.SetDefaultTTL(config.CacheTTL())
-	}
+	cacheManager, closeCacheManager := newCacheManager()
+	defer closeCacheManager()
 
 	location, err := time.LoadLocation("Asia/Jakarta")
 	continueOrFatal(err)
@@ -64,7 +55,29 @@ func runServer(cmd *cobra.Command, args []string) {
 	time.Local = location
 
 	employeeRepository := repository.NewEmployeeRepository(db.PostgreSQL, cacheManager)
-	employeeUsecase := usecase.NewEmployeeUsecase(employeeRepository)
+	departmentRepository := repository.NewDepartmentRepository(db.PostgreSQL, cacheManager)
+	positionRepository := repository.NewPositionRepository(db.PostgreSQL, cacheManager)
+	exchangeRateRepository := repository.NewExchangeRateRepository(db.PostgreSQL)
+	employeeUsecase := usecase.NewEmployeeUsecase(employeeRepository, departmentRepository, positionRepository, exchangeRateRepository)
+	departmentUsecase := usecase.NewDepartmentUsecase(departmentRepository)
+	positionUsecase := usecase.NewPositionUsecase(positionRepository)
+	exchangeRateUsecase := usecase.NewExchangeRateUsecase(exchangeRateRepository)
+
+	rateTables, err := payroll.LoadRateTables(config.PayrollRateTablesFile())
+	continueOrFatal(err)
+
+	payrollRepository := repository.NewPayrollRepository(db.PostgreSQL, cacheManager)
+	payrollUsecase := usecase.NewPayrollUsecase(employeeRepository, payrollRepository, exchangeRateRepository, rateTables)
+
+	tokenVerifier, err := auth.NewJWTVerifier(auth.JWTOptions{
+		Algorithm:     config.JWTAlgorithm(),
+		Secret:        config.JWTSecret(),
+		PublicKeyFile: config.JWTPublicKeyFile(),
+		Issuer:        config.JWTIssuer(),
+		Audience:      config.JWTAudience(),
+		Leeway:        config.JWTLeeway(),
+	})
+	continueOrFatal(err)
 
 	httpServer := echo.New()
 	httpServer.Pre(middleware.AddTrailingSlash())
@@ -72,8 +85,14 @@ func runServer(cmd *cobra.Command, args []string) {
 	httpServer.Use(middleware.Recover())
 	httpServer.Use(middleware.CORS())
 
-	apiGroup := httpServer.Group("/api")
-	httpsvc.RouteService(apiGroup, employeeUsecase)
+	apiGroup := httpServer.Group("/api", httpsvc.AuthMiddleware(tokenVerifier))
+	httpsvc.RouteService(apiGroup, employeeUsecase, departmentUsecase, positionUsecase, payrollUsecase, exchangeRateUsecase)
+
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(grpcsvc.AuthUnaryInterceptor(tokenVerifier)))
+	grpcsvc.RegisterService(grpcServer, employeeUsecase)
+
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
 
 	sigCh := make(chan os.Signal, 1)
 	errCh := make(chan error, 1)
@@ -84,11 +103,13 @@ func runServer(cmd *cobra.Command, args []string) {
 		for {
 			select {
 			case <-sigCh:
-				gracefulShutdown(httpServer)
+				stopWorkers()
+				gracefulShutdown(httpServer, grpcServer)
 				quitCh <- true
 			case e := <-errCh:
 				log.Error(e)
-				gracefulShutdown(httpServer)
+				stopWorkers()
+				gracefulShutdown(httpServer, grpcServer)
 				quitCh <- true
 			}
 		}
@@ -96,6 +117,8 @@ func runServer(cmd *cobra.Command, args []string) {
 
 	setupLogger()
 
+	go runSalaryChangeWorker(workerCtx, employeeUsecase, config.SalaryChangeApplyInterval())
+
 	go func() {
 		// Start HTTP server
 		if err := httpServer.Start(fmt.Sprintf(":%s", config.HTTPPort())); err != nil && err != http.ErrServerClosed {
@@ -103,11 +126,24 @@ func runServer(cmd *cobra.Command, args []string) {
 		}
 	}()
 
+	go func() {
+		// Start gRPC server
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%s", config.GRPCPort()))
+		if err != nil {
+			errCh <- err
+			return
+		}
+
+		if err := grpcServer.Serve(listener); err != nil && err != grpc.ErrServerStopped {
+			errCh <- err
+		}
+	}()
+
 	<-quitCh
 	log.Info("exiting")
 }
 
-func gracefulShutdown(httpSvr *echo.Echo) {
+func gracefulShutdown(httpSvr *echo.Echo, grpcSvr *grpc.Server) {
 	db.StopTickerCh <- true
 
 	if httpSvr != nil {
@@ -117,6 +153,59 @@ func gracefulShutdown(httpSvr *echo.Echo) {
 			httpSvr.Logger.Fatal(err)
 		}
 	}
+
+	if grpcSvr != nil {
+		grpcSvr.GracefulStop()
+	}
+}
+
+// runSalaryChangeWorker apply the salary changes which became effective, at start then on every interval,
+// until the context is done
+func runSalaryChangeWorker(ctx context.Context, employeeUsecase model.EmployeeUsecase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		applied, err := employeeUsecase.ApplyDueSalaryChanges(ctx)
+		if err != nil {
+			logrus.WithError(err).Error("failed to apply the due salary changes")
+		} else if applied > 0 {
+			logrus.WithField("applied", applied).Info("due salary changes applied")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// newCacheManager create the cache manager with its redis connections,
+// the returned function closes the connections
+func newCacheManager() (cacher.CacheManager, func()) {
+	cacheManager := cacher.NewCacheManager()
+
+	cacheManager.SetDisableCaching(config.DisableCaching())
+
+	if config.DisableCaching() {
+		return cacheManager, func() {}
+	}
+
+	redisConn, err := db.NewRedigoRedisConnectionPool(config.RedisCacheHost(), redisOpts)
+	continueOrFatal(err)
+
+	redisLockConn, err := db.NewRedigoRedisConnectionPool(config.RedisLockHost(), redisOpts)
+	continueOrFatal(err)
+
+	cacheManager.SetConnectionPool(redisConn)
+	cacheManager.SetLockConnectionPool(redisLockConn)
+	cacheManager.SetDefaultTTL(config.CacheTTL())
+
+	return cacheManager, func() {
+		helper.WrapCloser(redisConn.Close)
+		helper.WrapCloser(redisLockConn.Close)
+	}
 }
 
 func continueOrFatal(err error) {
@@ -0,0 +1,62 @@
+package grpc
+
+import (
+	"context"
+	"github.com/irvankadhafi/employee-api/internal/auth"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	pb "github.com/irvankadhafi/employee-api/pb/employee"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+func toEmployeeProto(ctx context.Context, employee *model.Employee) *pb.Employee {
+	if employee == nil {
+		return nil
+	}
+
+	return newEmployeeProto(employee, canReadSalary(ctx))
+}
+
+func toEmployeeProtos(ctx context.Context, employees []*model.Employee) []*pb.Employee {
+	showSalary := canReadSalary(ctx)
+
+	items := make([]*pb.Employee, 0, len(employees))
+	for _, employee := range employees {
+		items = append(items, newEmployeeProto(employee, showSalary))
+	}
+
+	return items
+}
+
+// newEmployeeProto convert the employee to proto, the salary is left unset when showSalary is false
+func newEmployeeProto(employee *model.Employee, showSalary bool) *pb.Employee {
+	employeeProto := &pb.Employee{
+		Id:           employee.ID,
+		Name:         employee.Name,
+		Position:     employee.Position,
+		Version:      employee.Version,
+		DepartmentId: employee.DepartmentID,
+		ManagerId:    employee.ManagerID,
+		CreatedAt:    toTimestampProto(employee.CreatedAt),
+		UpdatedAt:    toTimestampProto(employee.UpdatedAt),
+	}
+	if showSalary {
+		salary, currency := int64(employee.Salary), employee.Currency
+		employeeProto.SalaryMinor = &salary
+		employeeProto.Currency = &currency
+	}
+
+	return employeeProto
+}
+
+func canReadSalary(ctx context.Context) bool {
+	return auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionEmployeeSalaryRead)
+}
+
+func toTimestampProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
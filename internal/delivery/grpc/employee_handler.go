@@ -0,0 +1,179 @@
+package grpc
+
+import (
+	"context"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/irvankadhafi/employee-api/internal/usecase"
+	pb "github.com/irvankadhafi/employee-api/pb/employee"
+	"github.com/sirupsen/logrus"
+)
+
+func (s *service) Create(ctx context.Context, req *pb.CreateEmployeeRequest) (*pb.Employee, error) {
+	newEmployee, err := s.employeeUsecase.Create(ctx, model.CreateEmployeeRequest{
+		Name:               req.GetName(),
+		Position:           req.GetPosition(),
+		Salary:             model.Money(req.GetSalaryMinor()),
+		Currency:           req.GetCurrency(),
+		DepartmentID:       req.DepartmentId,
+		ManagerID:          req.ManagerId,
+		OverrideSalaryBand: req.GetOverrideSalaryBand(),
+	})
+	switch err {
+	case nil:
+		break
+	case usecase.ErrDuplicateEmployee:
+		return nil, ErrEmployeeAlreadyExist
+	case usecase.ErrDepartmentNotFound:
+		return nil, ErrDepartmentNotFound
+	case usecase.ErrManagerNotFound:
+		return nil, ErrManagerNotFound
+	case usecase.ErrManagerCycle:
+		return nil, ErrManagerCycle
+	case usecase.ErrPositionNotFound:
+		return nil, ErrPositionNotFound
+	case usecase.ErrSalaryOutOfBand:
+		return nil, ErrSalaryOutOfBand
+	case usecase.ErrInvalidCurrency:
+		return nil, ErrInvalidCurrency
+	case usecase.ErrNoExchangeRate:
+		return nil, ErrNoExchangeRate
+	case usecase.ErrPermissionDenied:
+		return nil, ErrPermissionDenied
+	default:
+		logrus.Error(err)
+		return nil, grpcValidationOrInternalErr(err)
+	}
+
+	return toEmployeeProto(ctx, newEmployee), nil
+}
+
+func (s *service) FindByID(ctx context.Context, req *pb.FindByIDRequest) (*pb.Employee, error) {
+	employee, err := s.employeeUsecase.FindByID(ctx, req.GetId())
+	switch err {
+	case nil:
+		break
+	case usecase.ErrNotFound:
+		return nil, ErrNotFound
+	case usecase.ErrPermissionDenied:
+		return nil, ErrPermissionDenied
+	default:
+		logrus.WithField("employee_id", req.GetId()).Error(err)
+		return nil, ErrInternal
+	}
+
+	return toEmployeeProto(ctx, employee), nil
+}
+
+func (s *service) Update(ctx context.Context, req *pb.UpdateEmployeeRequest) (*pb.Employee, error) {
+	employee, err := s.employeeUsecase.Update(ctx, req.GetId(), model.UpdateEmployeeRequest{
+		Name:               req.GetName(),
+		Position:           req.GetPosition(),
+		Salary:             model.Money(req.GetSalaryMinor()),
+		Currency:           req.GetCurrency(),
+		DepartmentID:       req.DepartmentId,
+		ManagerID:          req.ManagerId,
+		OverrideSalaryBand: req.GetOverrideSalaryBand(),
+	}, req.GetExpectedVersion())
+	switch err {
+	case nil:
+		break
+	case usecase.ErrNotFound:
+		return nil, ErrNotFound
+	case usecase.ErrDepartmentNotFound:
+		return nil, ErrDepartmentNotFound
+	case usecase.ErrManagerNotFound:
+		return nil, ErrManagerNotFound
+	case usecase.ErrManagerCycle:
+		return nil, ErrManagerCycle
+	case usecase.ErrPositionNotFound:
+		return nil, ErrPositionNotFound
+	case usecase.ErrSalaryOutOfBand:
+		return nil, ErrSalaryOutOfBand
+	case usecase.ErrInvalidCurrency:
+		return nil, ErrInvalidCurrency
+	case usecase.ErrNoExchangeRate:
+		return nil, ErrNoExchangeRate
+	case usecase.ErrPermissionDenied:
+		return nil, ErrPermissionDenied
+	case usecase.ErrPreconditionFailed:
+		return nil, ErrPreconditionFailed
+	case usecase.ErrConflict:
+		return nil, ErrConflict
+	default:
+		logrus.WithField("employee_id", req.GetId()).Error(err)
+		return nil, grpcValidationOrInternalErr(err)
+	}
+
+	return toEmployeeProto(ctx, employee), nil
+}
+
+func (s *service) Delete(ctx context.Context, req *pb.DeleteEmployeeRequest) (*pb.DeleteEmployeeResponse, error) {
+	err := s.employeeUsecase.DeleteByID(ctx, req.GetId(), req.GetExpectedVersion())
+	switch err {
+	case nil:
+		break
+	case usecase.ErrNotFound:
+		return nil, ErrNotFound
+	case usecase.ErrPermissionDenied:
+		return nil, ErrPermissionDenied
+	case usecase.ErrPreconditionFailed:
+		return nil, ErrPreconditionFailed
+	case usecase.ErrConflict:
+		return nil, ErrConflict
+	default:
+		logrus.WithField("employee_id", req.GetId()).Error(err)
+		return nil, ErrInternal
+	}
+
+	return &pb.DeleteEmployeeResponse{Id: req.GetId()}, nil
+}
+
+func (s *service) Search(ctx context.Context, req *pb.SearchEmployeesRequest) (*pb.SearchEmployeesResponse, error) {
+	searchCriteria := model.EmployeeSearchCriteria{
+		Name:         req.GetName(),
+		Position:     req.GetPosition(),
+		Page:         req.GetPage(),
+		Size:         req.GetSize(),
+		SortBy:       req.GetSortBy(),
+		SortDir:      req.GetSortDir(),
+		DepartmentID: req.GetDepartmentId(),
+	}
+	searchCriteria.SetDefaultValue()
+
+	employees, count, err := s.employeeUsecase.SearchByCriteria(ctx, searchCriteria)
+	switch err {
+	case nil:
+		break
+	case usecase.ErrPermissionDenied:
+		return nil, ErrPermissionDenied
+	case usecase.ErrInvalidSortField:
+		return nil, ErrInvalidSortField
+	default:
+		logrus.WithError(err).Error("failed to retrieve employees")
+		return nil, ErrInternal
+	}
+
+	return &pb.SearchEmployeesResponse{
+		Items: toEmployeeProtos(ctx, employees),
+		Count: count,
+		Page:  searchCriteria.Page,
+		Size:  searchCriteria.Size,
+	}, nil
+}
+
+func (s *service) ListPositions(ctx context.Context, _ *pb.ListPositionsRequest) (*pb.ListPositionsResponse, error) {
+	positions, err := s.employeeUsecase.GetDistinctPositions(ctx)
+	switch err {
+	case nil:
+		break
+	case usecase.ErrNotFound:
+		return &pb.ListPositionsResponse{}, nil
+	case usecase.ErrPermissionDenied:
+		return nil, ErrPermissionDenied
+	default:
+		logrus.Error(err)
+		return nil, ErrInternal
+	}
+
+	return &pb.ListPositionsResponse{Positions: positions}, nil
+}
@@ -0,0 +1,43 @@
+package grpc
+
+import (
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+var (
+	ErrInvalidArgument      = status.Error(codes.InvalidArgument, "invalid argument")
+	ErrInternal             = status.Error(codes.Internal, "internal system error")
+	ErrNotFound             = status.Error(codes.NotFound, "record not found")
+	ErrEmployeeAlreadyExist = status.Error(codes.AlreadyExists, "employee already exist")
+	ErrUnauthenticated      = status.Error(codes.Unauthenticated, "unauthenticated")
+	ErrPermissionDenied     = status.Error(codes.PermissionDenied, "permission denied")
+	ErrPreconditionFailed   = status.Error(codes.FailedPrecondition, "precondition failed, the employee has been modified")
+	ErrConflict             = status.Error(codes.Aborted, "the employee was modified concurrently, please retry")
+	ErrDepartmentNotFound   = status.Error(codes.InvalidArgument, "department not found")
+	ErrManagerNotFound      = status.Error(codes.InvalidArgument, "manager not found")
+	ErrManagerCycle         = status.Error(codes.InvalidArgument, "the employee can't report to itself or to one of its reports")
+	ErrPositionNotFound     = status.Error(codes.InvalidArgument, "position not found in the positions catalog")
+	ErrSalaryOutOfBand      = status.Error(codes.InvalidArgument, "salary is out of the band of the position")
+	ErrInvalidCurrency      = status.Error(codes.InvalidArgument, "invalid currency, use an ISO 4217 code like IDR, USD or SGD")
+	ErrNoExchangeRate       = status.Error(codes.InvalidArgument, "no exchange rate is effective on the day for the currency of the salary")
+	ErrInvalidSortField     = status.Error(codes.InvalidArgument, "invalid sort, use a comma separated list of id, name, position, salary, created_at or updated_at, each prefixed with - for a descending order")
+)
+
+// grpcValidationOrInternalErr return validation or internal error
+func grpcValidationOrInternalErr(err error) error {
+	validationErrors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return ErrInternal
+	}
+
+	var fields []string
+	for _, validationError := range validationErrors {
+		fields = append(fields, fmt.Sprintf("%s: failed on the '%s' tag", validationError.Field(), validationError.Tag()))
+	}
+
+	return status.Error(codes.InvalidArgument, strings.Join(fields, ", "))
+}
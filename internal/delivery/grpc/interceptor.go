@@ -0,0 +1,35 @@
+package grpc
+
+import (
+	"context"
+	"github.com/irvankadhafi/employee-api/internal/auth"
+	"github.com/sirupsen/logrus"
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// AuthUnaryInterceptor verify the bearer token from the "authorization" metadata
+// and set the authenticated user to the request context
+func AuthUnaryInterceptor(verifier auth.TokenVerifier) googlegrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *googlegrpc.UnaryServerInfo, handler googlegrpc.UnaryHandler) (any, error) {
+		var authorization string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get("authorization"); len(values) > 0 {
+				authorization = values[0]
+			}
+		}
+
+		token, err := auth.ParseBearerToken(authorization)
+		if err != nil {
+			return nil, ErrUnauthenticated
+		}
+
+		user, err := verifier.Verify(token)
+		if err != nil {
+			logrus.WithField("method", info.FullMethod).Warn(err)
+			return nil, ErrUnauthenticated
+		}
+
+		return handler(auth.SetUserToCtx(ctx, user), req)
+	}
+}
@@ -0,0 +1,26 @@
+package grpc
+
+import (
+	"github.com/irvankadhafi/employee-api/internal/model"
+	pb "github.com/irvankadhafi/employee-api/pb/employee"
+	googlegrpc "google.golang.org/grpc"
+)
+
+// service grpc service
+type service struct {
+	pb.UnimplementedEmployeeServiceServer
+
+	employeeUsecase model.EmployeeUsecase
+}
+
+// RegisterService register employee service to the grpc server
+func RegisterService(
+	server *googlegrpc.Server,
+	employeeUsecase model.EmployeeUsecase,
+) {
+	svc := &service{
+		employeeUsecase: employeeUsecase,
+	}
+
+	pb.RegisterEmployeeServiceServer(server, svc)
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/irvankadhafi/employee-api/utils"
 	"github.com/labstack/echo/v4"
+	"mime"
 	"strconv"
 	"sync"
 )
@@ -56,6 +57,31 @@ func toResourcePaginationResponse[T any](page, size int, count int64, items []T)
 	return pagination
 }
 
+type cursorMetaInfo struct {
+	Size       int    `json:"size"`
+	Count      *int64 `json:"count,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+type cursorPaginationResponse[T any] struct {
+	Items          []T `json:"items"`
+	cursorMetaInfo `json:"meta_info"`
+}
+
+// toCursorPaginationResponse build the response of a keyset paginated search, count is omitted when it was not requested
+func toCursorPaginationResponse[T any](size int, count *int64, nextCursor string, items []T) cursorPaginationResponse[T] {
+	return cursorPaginationResponse[T]{
+		Items: items,
+		cursorMetaInfo: cursorMetaInfo{
+			Size:       size,
+			Count:      count,
+			NextCursor: nextCursor,
+			HasMore:    nextCursor != "",
+		},
+	}
+}
+
 type successResponse struct {
 	Success bool `json:"success"`
 	Data    any  `json:"data"`
@@ -88,3 +114,25 @@ func parseQueryParam(c echo.Context, param string, defaultValue int) (int, error
 	}
 	return strconv.Atoi(paramStr)
 }
+
+// parseBoolQueryParam is a helper function to parse and return a bool from a query param or fallback to default.
+func parseBoolQueryParam(c echo.Context, param string, defaultValue bool) (bool, error) {
+	paramStr := c.QueryParam(param)
+	if paramStr == "" {
+		return defaultValue, nil
+	}
+	return strconv.ParseBool(paramStr)
+}
+
+// mergePatchContentType is the media type of a RFC 7396 JSON merge patch document
+const mergePatchContentType = "application/merge-patch+json"
+
+// isMergePatchContentType check whether the content type is a merge patch, plain JSON is accepted as well
+func isMergePatchContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == mergePatchContentType || mediaType == echo.MIMEApplicationJSON
+}
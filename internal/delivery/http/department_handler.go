@@ -0,0 +1,151 @@
+package http
+
+import (
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/irvankadhafi/employee-api/internal/usecase"
+	"github.com/irvankadhafi/employee-api/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+func (s *service) CreateDepartment() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		req := model.CreateDepartmentRequest{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		department, err := s.departmentUsecase.Create(ctx, req)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrDuplicateDepartment:
+			return ErrDepartmentAlreadyExist
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		default:
+			logrus.Error(err)
+			return httpValidationOrInternalErr(err)
+		}
+
+		return c.JSON(http.StatusCreated, setSuccessResponse(department))
+	}
+}
+
+func (s *service) GetDepartmentDetail() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		departmentID := utils.StringToInt64(c.Param("department_id"))
+
+		department, err := s.departmentUsecase.FindByID(ctx, departmentID)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		default:
+			logrus.WithField("department_id", departmentID).Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, setSuccessResponse(department))
+	}
+}
+
+func (s *service) SearchDepartments() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		page, err := parseQueryParam(c, "page", 1)
+		if err != nil {
+			logrus.WithError(err).Error("failed to parse page")
+			return ErrInvalidArgument
+		}
+
+		limit, err := parseQueryParam(c, "limit", 10)
+		if err != nil {
+			logrus.WithError(err).Error("failed to parse limit")
+			return ErrInvalidArgument
+		}
+
+		criteria := model.DepartmentCriteria{
+			Name: c.QueryParam("name"),
+			Page: int64(page),
+			Size: int64(limit),
+		}
+
+		departments, count, err := s.departmentUsecase.FindAllByCriteria(ctx, criteria)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		default:
+			logrus.WithError(err).Error("failed to retrieve departments")
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, toResourcePaginationResponse(page, limit, count, departments))
+	}
+}
+
+func (s *service) UpdateDepartment() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		req := model.UpdateDepartmentRequest{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+		departmentID := utils.StringToInt64(c.Param("department_id"))
+
+		department, err := s.departmentUsecase.Update(ctx, departmentID, req)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		case usecase.ErrDuplicateDepartment:
+			return ErrDepartmentAlreadyExist
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		default:
+			logrus.Error(err)
+			return httpValidationOrInternalErr(err)
+		}
+
+		return c.JSON(http.StatusOK, setSuccessResponse(department))
+	}
+}
+
+// DeleteDepartment delete an empty department, it is refused with 409 while employees still belong to it
+func (s *service) DeleteDepartment() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		departmentID := utils.StringToInt64(c.Param("department_id"))
+
+		err := s.departmentUsecase.DeleteByID(ctx, departmentID)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		case usecase.ErrDepartmentHasMembers:
+			return ErrDepartmentHasMembers
+		default:
+			logrus.WithField("department_id", departmentID).Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, setSuccessResponse(departmentID))
+	}
+}
@@ -0,0 +1,198 @@
+package http
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/irvankadhafi/employee-api/utils"
+	"github.com/xuri/excelize/v2"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	exportFormatCSV  = "csv"
+	exportFormatXLSX = "xlsx"
+
+	mimeTextCSV = "text/csv; charset=utf-8"
+	mimeXLSX    = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+	exportSheetName = "Employees"
+)
+
+// employeeExportWriter writes the rows of an export, Flush is called after every batch and Close once at the end
+type employeeExportWriter interface {
+	WriteRow(values []any) error
+	Flush() error
+	Close() error
+}
+
+// newEmployeeExportWriter return the writer of the format and its content type
+func newEmployeeExportWriter(format string, w io.Writer) (employeeExportWriter, string, error) {
+	switch format {
+	case exportFormatCSV:
+		return &csvExportWriter{w: w, writer: csv.NewWriter(w)}, mimeTextCSV, nil
+	case exportFormatXLSX:
+		writer, err := newXLSXExportWriter(w)
+		return writer, mimeXLSX, err
+	default:
+		return nil, "", fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// employeeExportColumns the header row of an export, salary is only listed when it can be read,
+// along with the reporting salary when the salaries are normalized
+func employeeExportColumns(showSalary, reporting bool) []any {
+	columns := []any{"id", "external_key", "name", "position"}
+	if showSalary {
+		columns = append(columns, "salary", "currency")
+		if reporting {
+			columns = append(columns, "reporting_salary", "reporting_currency")
+		}
+	}
+
+	return append(columns, "created_at", "updated_at")
+}
+
+// toEmployeeExportRow return the values of the employee in the order of employeeExportColumns
+func toEmployeeExportRow(employee *model.Employee, showSalary, reporting, rupiah bool) []any {
+	var externalKey string
+	if employee.ExternalKey != nil {
+		externalKey = *employee.ExternalKey
+	}
+
+	row := []any{employee.ID, externalKey, employee.Name, employee.Position}
+	if showSalary {
+		row = append(row, formatExportMoney(employee.Salary, employee.Currency, rupiah), employee.Currency)
+		if reporting {
+			var reportingSalary any = ""
+			if employee.ReportingSalary != nil {
+				reportingSalary = formatExportMoney(*employee.ReportingSalary, employee.ReportingCurrency, rupiah)
+			}
+			row = append(row, reportingSalary, employee.ReportingCurrency)
+		}
+	}
+
+	return append(row, formatExportTime(employee.CreatedAt), formatExportTime(employee.UpdatedAt))
+}
+
+// writeEmployeeExport write the batch of employees, the salary is left out when the caller can't read it
+func writeEmployeeExport(ctx context.Context, writer employeeExportWriter, employees []*model.Employee, reporting, rupiah bool) error {
+	showSalary := canReadSalary(ctx)
+	for _, employee := range employees {
+		if err := writer.WriteRow(toEmployeeExportRow(employee, showSalary, reporting, rupiah)); err != nil {
+			return err
+		}
+	}
+
+	return writer.Flush()
+}
+
+// formatExportMoney return the amount as a plain number, or formatted in Rupiah when rupiah is asked
+// and the amount is in Rupiah
+func formatExportMoney(amount model.Money, currency string, rupiah bool) any {
+	if rupiah && currency == model.BaseCurrency {
+		return amount.Rupiah()
+	}
+
+	return amount.Float64()
+}
+
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+// csvExportWriter streams the rows to the response, every batch is flushed to the client
+type csvExportWriter struct {
+	w      io.Writer
+	writer *csv.Writer
+}
+
+func (c *csvExportWriter) WriteRow(values []any) error {
+	record := make([]string, len(values))
+	for idx, value := range values {
+		switch v := value.(type) {
+		case string:
+			record[idx] = v
+		case int64:
+			record[idx] = strconv.FormatInt(v, 10)
+		case float64:
+			record[idx] = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			record[idx] = fmt.Sprint(v)
+		}
+	}
+
+	return c.writer.Write(record)
+}
+
+func (c *csvExportWriter) Flush() error {
+	c.writer.Flush()
+	if err := c.writer.Error(); err != nil {
+		return err
+	}
+
+	if flusher, ok := c.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+
+	return nil
+}
+
+func (c *csvExportWriter) Close() error {
+	return c.Flush()
+}
+
+// xlsxExportWriter writes the rows with the excelize stream writer, which spills them to a temporary file
+// instead of keeping them in memory. The workbook is only written out on Close, since it is a zip archive.
+type xlsxExportWriter struct {
+	w      io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	rowNum int
+}
+
+func newXLSXExportWriter(w io.Writer) (*xlsxExportWriter, error) {
+	file := excelize.NewFile()
+	if err := file.SetSheetName("Sheet1", exportSheetName); err != nil {
+		return nil, err
+	}
+
+	stream, err := file.NewStreamWriter(exportSheetName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &xlsxExportWriter{w: w, file: file, stream: stream}, nil
+}
+
+func (x *xlsxExportWriter) WriteRow(values []any) error {
+	x.rowNum++
+	cell, err := excelize.CoordinatesToCellName(1, x.rowNum)
+	if err != nil {
+		return err
+	}
+
+	return x.stream.SetRow(cell, values)
+}
+
+func (x *xlsxExportWriter) Flush() error {
+	return nil
+}
+
+func (x *xlsxExportWriter) Close() error {
+	defer utils.WrapCloser(x.file.Close)
+
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+
+	return x.file.Write(x.w)
+}
@@ -0,0 +1,215 @@
+package http
+
+import (
+	"fmt"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// filterParamPattern matches the field[operator] and not[field][operator] query params
+var filterParamPattern = regexp.MustCompile(`^(?:not\[(\w+)\]|(\w+))\[(\w+)\]$`)
+
+// parseEmployeeFilter read the field[operator]=value query params into a filter, e.g. salary[gte]=10000000.
+// The in and nin operators take a comma separated list, the not[field][operator] params are negated as a group.
+// The query params which are not a condition, like page or sort, are ignored.
+func parseEmployeeFilter(params url.Values) (filter model.EmployeeFilter, err error) {
+	for key, values := range params {
+		if key == "include_deleted" {
+			filter.IncludeDeleted, err = strconv.ParseBool(values[0])
+			if err != nil {
+				return filter, fmt.Errorf("invalid filter %q: must be a boolean", key)
+			}
+			continue
+		}
+
+		match := filterParamPattern.FindStringSubmatch(key)
+		if match == nil {
+			continue
+		}
+
+		target, field, operator := &filter, match[2], match[3]
+		if match[1] != "" {
+			if filter.Not == nil {
+				filter.Not = &model.EmployeeFilter{}
+			}
+			target, field = filter.Not, match[1]
+		}
+
+		if err := setFilterCondition(target, field, operator, values); err != nil {
+			return filter, fmt.Errorf("invalid filter %q: %w", key, err)
+		}
+	}
+
+	return filter, nil
+}
+
+func setFilterCondition(filter *model.EmployeeFilter, field, operator string, values []string) error {
+	switch field {
+	case "name":
+		return setStringCondition(&filter.Name, operator, values)
+	case "position":
+		return setStringCondition(&filter.Position, operator, values)
+	case "salary":
+		return setRangeCondition(&filter.Salary, operator, values[0], parseFilterMoney)
+	case "created_at":
+		return setRangeCondition(&filter.CreatedAt, operator, values[0], parseFilterTime)
+	case "updated_at":
+		return setRangeCondition(&filter.UpdatedAt, operator, values[0], parseFilterTime)
+	case "department_id":
+		return setIDCondition(&filter.DepartmentID, operator, values)
+	default:
+		return fmt.Errorf("unknown field, use name, position, salary, created_at, updated_at or department_id")
+	}
+}
+
+func setStringCondition(target **model.StringFilter, operator string, values []string) error {
+	if *target == nil {
+		*target = &model.StringFilter{}
+	}
+
+	condition := *target
+	value := values[0]
+	switch operator {
+	case "eq":
+		condition.Eq = &value
+	case "ne":
+		condition.Ne = &value
+	case "like":
+		condition.Like = &value
+	case "in":
+		condition.In = splitFilterList(values)
+	case "nin":
+		condition.Nin = splitFilterList(values)
+	default:
+		return fmt.Errorf("unknown operator, use eq, ne, like, in or nin")
+	}
+
+	return nil
+}
+
+func setIDCondition(target **model.IDFilter, operator string, values []string) error {
+	if *target == nil {
+		*target = &model.IDFilter{}
+	}
+
+	condition := *target
+	switch operator {
+	case "eq", "ne":
+		id, err := parseFilterID(values[0])
+		if err != nil {
+			return err
+		}
+
+		if operator == "eq" {
+			condition.Eq = &id
+		} else {
+			condition.Ne = &id
+		}
+	case "in", "nin":
+		var ids []int64
+		for _, item := range splitFilterList(values) {
+			id, err := parseFilterID(item)
+			if err != nil {
+				return err
+			}
+			ids = append(ids, id)
+		}
+
+		if operator == "in" {
+			condition.In = ids
+		} else {
+			condition.Nin = ids
+		}
+	case "null":
+		null, err := strconv.ParseBool(values[0])
+		if err != nil {
+			return fmt.Errorf("must be a boolean")
+		}
+		condition.Null = &null
+	default:
+		return fmt.Errorf("unknown operator, use eq, ne, in, nin or null")
+	}
+
+	return nil
+}
+
+func setRangeCondition[T model.Money | time.Time](target **model.RangeFilter[T], operator, value string, parse func(string) (T, error)) error {
+	if *target == nil {
+		*target = &model.RangeFilter[T]{}
+	}
+
+	parsed, err := parse(value)
+	if err != nil {
+		return err
+	}
+
+	condition := *target
+	switch operator {
+	case "eq":
+		condition.Eq = &parsed
+	case "ne":
+		condition.Ne = &parsed
+	case "gt":
+		condition.Gt = &parsed
+	case "gte":
+		condition.Gte = &parsed
+	case "lt":
+		condition.Lt = &parsed
+	case "lte":
+		condition.Lte = &parsed
+	default:
+		return fmt.Errorf("unknown operator, use eq, ne, gt, gte, lt or lte")
+	}
+
+	return nil
+}
+
+// splitFilterList accept both a comma separated list and the repeated query param
+func splitFilterList(values []string) []string {
+	var items []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+
+	return items
+}
+
+func parseFilterID(value string) (int64, error) {
+	id, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("must be an integer id")
+	}
+
+	return id, nil
+}
+
+func parseFilterMoney(value string) (model.Money, error) {
+	amount, err := model.ParseMoney(value)
+	if err != nil {
+		return 0, fmt.Errorf("must be a number with at most 2 decimals")
+	}
+
+	return amount, nil
+}
+
+// parseFilterTime accept a RFC 3339 time or a date, which is the start of the day in the local time
+func parseFilterTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("must be a RFC 3339 time or a YYYY-MM-DD date")
+	}
+
+	return t, nil
+}
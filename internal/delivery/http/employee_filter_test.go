@@ -0,0 +1,137 @@
+package http
+
+import (
+	"encoding/json"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseEmployeeFilter(t *testing.T) {
+	day := time.Date(2026, time.January, 2, 0, 0, 0, 0, time.Local)
+	at := time.Date(2026, time.March, 4, 5, 6, 7, 0, time.UTC)
+	str := func(s string) *string { return &s }
+	id := func(i int64) *int64 { return &i }
+	money := func(m model.Money) *model.Money { return &m }
+	yes := true
+
+	tests := []struct {
+		name  string
+		query string
+		want  model.EmployeeFilter
+	}{
+		{
+			name:  "no condition",
+			query: "page=2&size=10&sort=-salary&q=sastro",
+			want:  model.EmployeeFilter{},
+		},
+		{
+			name:  "string conditions",
+			query: "name[eq]=Budi&position[like]=eng&position[ne]=CTO",
+			want: model.EmployeeFilter{
+				Name:     &model.StringFilter{Eq: str("Budi")},
+				Position: &model.StringFilter{Like: str("eng"), Ne: str("CTO")},
+			},
+		},
+		{
+			name:  "a comma separated list and a repeated param",
+			query: "position[in]=CTO, Engineer,&position[in]=Designer&name[nin]=Budi",
+			want: model.EmployeeFilter{
+				Name:     &model.StringFilter{Nin: []string{"Budi"}},
+				Position: &model.StringFilter{In: []string{"CTO", "Engineer", "Designer"}},
+			},
+		},
+		{
+			name:  "a salary range",
+			query: "salary[gte]=10000000&salary[lt]=20000000.50",
+			want: model.EmployeeFilter{
+				Salary: &model.RangeFilter[model.Money]{Gte: money(1000000000), Lt: money(2000000050)},
+			},
+		},
+		{
+			name:  "time ranges",
+			query: "created_at[gt]=2026-01-02&updated_at[lte]=2026-03-04T05:06:07Z",
+			want: model.EmployeeFilter{
+				CreatedAt: &model.RangeFilter[time.Time]{Gt: &day},
+				UpdatedAt: &model.RangeFilter[time.Time]{Lte: &at},
+			},
+		},
+		{
+			name:  "department conditions",
+			query: "department_id[in]=1,2&department_id[nin]=3&department_id[null]=true&department_id[ne]=4",
+			want: model.EmployeeFilter{
+				DepartmentID: &model.IDFilter{In: []int64{1, 2}, Nin: []int64{3}, Null: &yes, Ne: id(4)},
+			},
+		},
+		{
+			name:  "a negated group",
+			query: "not[name][eq]=Budi&not[salary][gt]=5000000&position[eq]=CTO",
+			want: model.EmployeeFilter{
+				Position: &model.StringFilter{Eq: str("CTO")},
+				Not: &model.EmployeeFilter{
+					Name:   &model.StringFilter{Eq: str("Budi")},
+					Salary: &model.RangeFilter[model.Money]{Gt: money(500000000)},
+				},
+			},
+		},
+		{
+			name:  "the deleted employees",
+			query: "include_deleted=true&department_id[eq]=7",
+			want:  model.EmployeeFilter{IncludeDeleted: true, DepartmentID: &model.IDFilter{Eq: id(7)}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("url.ParseQuery(%q) error = %v", tt.query, err)
+			}
+
+			got, err := parseEmployeeFilter(params)
+			if err != nil {
+				t.Fatalf("parseEmployeeFilter(%q) error = %v", tt.query, err)
+			}
+
+			gotJSON, _ := json.Marshal(got)
+			wantJSON, _ := json.Marshal(tt.want)
+			if string(gotJSON) != string(wantJSON) {
+				t.Errorf("parseEmployeeFilter(%q) = %s, want %s", tt.query, gotJSON, wantJSON)
+			}
+		})
+	}
+}
+
+func TestParseEmployeeFilter_Invalid(t *testing.T) {
+	tests := []struct {
+		query   string
+		wantErr string
+	}{
+		{query: "include_deleted=maybe", wantErr: "must be a boolean"},
+		{query: "email[eq]=a@b.c", wantErr: "unknown field"},
+		{query: "name[gt]=Budi", wantErr: "unknown operator"},
+		{query: "salary[like]=100", wantErr: "unknown operator"},
+		{query: "salary[gte]=10.001", wantErr: "at most 2 decimals"},
+		{query: "salary[gte]=ten", wantErr: "at most 2 decimals"},
+		{query: "created_at[gte]=yesterday", wantErr: "RFC 3339"},
+		{query: "department_id[eq]=sales", wantErr: "integer id"},
+		{query: "department_id[in]=1,two", wantErr: "integer id"},
+		{query: "department_id[null]=maybe", wantErr: "must be a boolean"},
+		{query: "department_id[like]=1", wantErr: "unknown operator"},
+		{query: "not[email][eq]=a@b.c", wantErr: "unknown field"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			params, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("url.ParseQuery(%q) error = %v", tt.query, err)
+			}
+
+			_, err = parseEmployeeFilter(params)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseEmployeeFilter(%q) error = %v, want %q", tt.query, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,12 +1,15 @@
 package http
 
 import (
+	"fmt"
 	"github.com/irvankadhafi/employee-api/internal/model"
 	"github.com/irvankadhafi/employee-api/internal/usecase"
 	"github.com/irvankadhafi/employee-api/utils"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
+	"io"
 	"net/http"
+	"time"
 )
 
 func (s *service) Create() echo.HandlerFunc {
@@ -25,12 +28,147 @@ func (s *service) Create() echo.HandlerFunc {
 			break
 		case usecase.ErrDuplicateEmployee:
 			return ErrEmployeeAlreadyExist
+		case usecase.ErrDepartmentNotFound:
+			return ErrDepartmentNotFound
+		case usecase.ErrManagerNotFound:
+			return ErrManagerNotFound
+		case usecase.ErrManagerCycle:
+			return ErrManagerCycle
+		case usecase.ErrPositionNotFound:
+			return ErrPositionNotFound
+		case usecase.ErrSalaryOutOfBand:
+			return ErrSalaryOutOfBand
+		case usecase.ErrNoExchangeRate:
+			return ErrNoExchangeRate
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		default:
+			logrus.Error(err)
+			return httpValidationOrInternalErr(err)
+		}
+
+		c.Response().Header().Set("ETag", employeeETag(newEmployee, canReadSalary(ctx)))
+		return c.JSON(http.StatusCreated, setSuccessResponse(toEmployeeResponse(ctx, newEmployee)))
+	}
+}
+
+// BulkCreate create several employees at once. The items are inserted in one transaction unless
+// the best_effort query param is true, in which case every valid item is inserted on its own.
+func (s *service) BulkCreate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		req := model.BulkCreateEmployeeRequest{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		bestEffort, err := parseBoolQueryParam(c, "best_effort", false)
+		if err != nil {
+			logrus.WithError(err).Error("failed to parse best_effort")
+			return ErrInvalidArgument
+		}
+
+		results, err := s.employeeUsecase.BulkCreate(ctx, req, bestEffort)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		case usecase.ErrEmptyItems:
+			return ErrEmptyItems
+		case usecase.ErrTooManyItems:
+			return ErrTooManyItems
+		case usecase.ErrDepartmentNotFound:
+			return ErrDepartmentNotFound
+		case usecase.ErrManagerNotFound:
+			return ErrManagerNotFound
+		case usecase.ErrManagerCycle:
+			return ErrManagerCycle
+		case usecase.ErrPositionNotFound:
+			return ErrPositionNotFound
+		case usecase.ErrSalaryOutOfBand:
+			return ErrSalaryOutOfBand
+		case usecase.ErrNoExchangeRate:
+			return ErrNoExchangeRate
+		case usecase.ErrBulkCreateRejected:
+			return c.JSON(http.StatusUnprocessableEntity, errorResponse{
+				Success: false,
+				Message: results,
+			})
 		default:
 			logrus.Error(err)
 			return ErrInternal
 		}
 
-		return c.JSON(http.StatusCreated, setSuccessResponse(newEmployee))
+		statusCode := http.StatusCreated
+		for _, result := range results {
+			if result.Status != model.BulkCreateStatusCreated {
+				statusCode = http.StatusMultiStatus
+				break
+			}
+		}
+
+		return c.JSON(statusCode, setSuccessResponse(results))
+	}
+}
+
+// Import create or upsert the employees of the uploaded CSV file (multipart field "file").
+// With report=csv the row level error report is returned as a CSV attachment instead of the JSON summary.
+func (s *service) Import() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		dryRun, err := parseBoolQueryParam(c, "dry_run", false)
+		if err != nil {
+			logrus.WithError(err).Error("failed to parse dry_run")
+			return ErrInvalidArgument
+		}
+
+		upsert, err := parseBoolQueryParam(c, "upsert", false)
+		if err != nil {
+			logrus.WithError(err).Error("failed to parse upsert")
+			return ErrInvalidArgument
+		}
+
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			logrus.WithError(err).Error("failed to read the uploaded file")
+			return ErrInvalidArgument
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			logrus.Error(err)
+			return ErrInternal
+		}
+		defer utils.WrapCloser(file.Close)
+
+		result, err := s.employeeUsecase.Import(ctx, file, model.EmployeeImportOptions{
+			DryRun: dryRun,
+			Upsert: upsert,
+		})
+		switch err {
+		case nil:
+			break
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		case usecase.ErrInvalidCSVHeader:
+			return ErrInvalidCSVHeader
+		default:
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		if c.QueryParam("report") == "csv" {
+			c.Response().Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+			c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="import-errors.csv"`)
+			c.Response().WriteHeader(http.StatusOK)
+			return result.WriteErrorReport(c.Response())
+		}
+
+		return c.JSON(http.StatusOK, setSuccessResponse(result))
 	}
 }
 
@@ -43,31 +181,30 @@ func (s *service) GetDetail() echo.HandlerFunc {
 		})
 
 		employee, err := s.employeeUsecase.FindByID(ctx, employeeID)
-		if err != nil {
-			logger.Error(err)
-			return ErrInternal
-		}
-
 		switch err {
 		case nil:
 			break
 		case usecase.ErrNotFound:
 			return ErrNotFound
-		case usecase.ErrDuplicateEmployee:
-			return ErrEmployeeAlreadyExist
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
 		default:
-			logrus.Error(err)
+			logger.Error(err)
 			return ErrInternal
 		}
 
-		return c.JSON(http.StatusOK, setSuccessResponse(employee))
+		etag := employeeETag(employee, canReadSalary(ctx))
+		setValidators(c, etag, employee.UpdatedAt)
+		if isNotModified(c.Request(), etag, employee.UpdatedAt) {
+			return c.NoContent(http.StatusNotModified)
+		}
+
+		return c.JSON(http.StatusOK, setSuccessResponse(toEmployeeResponse(ctx, employee)))
 	}
 }
 
 func (s *service) SearchEmployees() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		ctx := c.Request().Context()
-
 		// Parse name parameters with default values
 		page, err := parseQueryParam(c, "page", 1)
 		if err != nil {
@@ -81,28 +218,329 @@ func (s *service) SearchEmployees() echo.HandlerFunc {
 			return ErrInvalidArgument
 		}
 
+		filter, err := parseEmployeeFilter(c.QueryParams())
+		if err != nil {
+			logrus.WithError(err).Error("failed to parse the filter")
+			return newInvalidFilterErr(err)
+		}
+
+		withCount, err := parseBoolQueryParam(c, "count", false)
+		if err != nil {
+			logrus.WithError(err).Error("failed to parse count")
+			return ErrInvalidArgument
+		}
+
+		departmentID, err := parseQueryParam(c, "department_id", 0)
+		if err != nil {
+			logrus.WithError(err).Error("failed to parse department_id")
+			return ErrInvalidArgument
+		}
+
 		// Define search criteria
 		searchCriteria := model.EmployeeSearchCriteria{
-			Name:     c.QueryParam("name"),
-			Position: c.QueryParam("position"),
-			Page:     int64(page),
-			Size:     int64(limit),
-			SortBy:   c.QueryParam("sort"),
-			SortDir:  c.QueryParam("dir"),
+			Name:         c.QueryParam("name"),
+			Position:     c.QueryParam("position"),
+			DepartmentID: int64(departmentID),
+			Page:         int64(page),
+			Size:         int64(limit),
+			SortBy:       c.QueryParam("sort"),
+			SortDir:      c.QueryParam("dir"),
+			Query:        c.QueryParam("q"),
+			Filter:       filter,
+			Cursor:       c.QueryParam("cursor"),
+			WithCount:    withCount,
+
+			ReportingCurrency: c.QueryParam("reporting_currency"),
+			RateDate:          c.QueryParam("rate_date"),
+		}
+
+		// the cursor pagination is opted in with pagination=cursor, or implied by a cursor
+		if searchCriteria.Cursor != "" || c.QueryParam("pagination") == "cursor" {
+			return s.searchEmployeesByCursor(c, searchCriteria)
+		}
+
+		// a query is ranked by relevance unless another sort is asked
+		if searchCriteria.Query != "" && searchCriteria.SortBy == "" {
+			return s.searchEmployeesByQuery(c, searchCriteria)
+		}
+
+		return s.searchEmployeesByPage(c, searchCriteria)
+	}
+}
+
+// searchEmployeesRequest the JSON body of POST /employees/search/, Pagination is "cursor" to opt in the cursor pagination
+type searchEmployeesRequest struct {
+	model.EmployeeSearchCriteria
+	Pagination string `json:"pagination"`
+}
+
+// SearchEmployeesByFilter is the search with the criteria, including the filter, given as a JSON body
+// for the filters which are awkward to put in a query string
+func (s *service) SearchEmployeesByFilter() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := searchEmployeesRequest{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		searchCriteria := req.EmployeeSearchCriteria
+		byRelevance := searchCriteria.Query != "" && searchCriteria.SortBy == ""
+		searchCriteria.SetDefaultValue()
+
+		switch {
+		case searchCriteria.Cursor != "" || req.Pagination == "cursor":
+			return s.searchEmployeesByCursor(c, searchCriteria)
+		case byRelevance:
+			return s.searchEmployeesByQuery(c, searchCriteria)
+		default:
+			return s.searchEmployeesByPage(c, searchCriteria)
+		}
+	}
+}
+
+func (s *service) searchEmployeesByPage(c echo.Context, searchCriteria model.EmployeeSearchCriteria) error {
+	ctx := c.Request().Context()
+
+	employees, count, err := s.employeeUsecase.SearchByCriteria(ctx, searchCriteria)
+	switch err {
+	case nil:
+		break
+	case usecase.ErrPermissionDenied:
+		return ErrPermissionDenied
+	case usecase.ErrInvalidSortField:
+		return ErrInvalidSortField
+	case usecase.ErrInvalidCurrency:
+		return ErrInvalidCurrency
+	case usecase.ErrInvalidRateDate:
+		return ErrInvalidRateDate
+	case usecase.ErrNoExchangeRate:
+		return ErrNoExchangeRate
+	default:
+		logrus.WithError(err).Error("failed to retrieve employees")
+		return httpValidationOrInternalErr(err)
+	}
+
+	page, limit := int(searchCriteria.Page), int(searchCriteria.Size)
+	logrus.WithFields(logrus.Fields{
+		"page":  page,
+		"limit": limit,
+	}).Info("success retrieving employees")
+
+	etag := employeesETag(employees, canReadSalary(ctx), int64(page), int64(limit), count)
+	setValidators(c, etag, nil)
+	if isNotModified(c.Request(), etag, nil) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	return c.JSON(http.StatusOK, toResourcePaginationResponse(page, limit, count, toEmployeeResponses(ctx, employees)))
+}
+
+// Export stream every employee matching the search filters as a CSV or XLSX file (format=csv|xlsx),
+// with rupiah=true the salaries in Rupiah are formatted instead of a plain number. With reporting_currency or
+// rate_date the salaries are normalized into the reporting currency as well.
+func (s *service) Export() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		format := c.QueryParam("format")
+		if format == "" {
+			format = exportFormatCSV
+		}
+
+		rupiah, err := parseBoolQueryParam(c, "rupiah", false)
+		if err != nil {
+			logrus.WithError(err).Error("failed to parse rupiah")
+			return ErrInvalidArgument
+		}
+
+		writer, contentType, err := newEmployeeExportWriter(format, c.Response())
+		if err != nil {
+			logrus.WithError(err).Error("failed to create the export writer")
+			return ErrInvalidArgument
+		}
+
+		filter, err := parseEmployeeFilter(c.QueryParams())
+		if err != nil {
+			logrus.WithError(err).Error("failed to parse the filter")
+			return newInvalidFilterErr(err)
+		}
+
+		departmentID, err := parseQueryParam(c, "department_id", 0)
+		if err != nil {
+			logrus.WithError(err).Error("failed to parse department_id")
+			return ErrInvalidArgument
+		}
+
+		searchCriteria := model.EmployeeSearchCriteria{
+			Name:         c.QueryParam("name"),
+			Position:     c.QueryParam("position"),
+			DepartmentID: int64(departmentID),
+			Query:        c.QueryParam("q"),
+			Filter:       filter,
+
+			ReportingCurrency: c.QueryParam("reporting_currency"),
+			RateDate:          c.QueryParam("rate_date"),
 		}
 
-		employees, count, err := s.employeeUsecase.SearchByCriteria(ctx, searchCriteria)
+		// the response is only committed with the first batch, so an early error is still returned as JSON
+		started := false
+		start := func() error {
+			started = true
+
+			header := c.Response().Header()
+			header.Set(echo.HeaderContentType, contentType)
+			header.Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="employees-%s.%s"`, time.Now().Format("20060102"), format))
+			c.Response().WriteHeader(http.StatusOK)
+
+			return writer.WriteRow(employeeExportColumns(canReadSalary(ctx), searchCriteria.IsReporting()))
+		}
+
+		err = s.employeeUsecase.Export(ctx, searchCriteria, func(employees []*model.Employee) error {
+			if !started {
+				if err := start(); err != nil {
+					return err
+				}
+			}
+
+			return writeEmployeeExport(ctx, writer, employees, searchCriteria.IsReporting(), rupiah)
+		})
+		switch {
+		case err == nil:
+			break
+		case started:
+			// the status is already sent, the client sees a truncated file
+			logrus.WithError(err).Error("failed to export employees")
+			return nil
+		case err == usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		case err == usecase.ErrInvalidCurrency:
+			return ErrInvalidCurrency
+		case err == usecase.ErrInvalidRateDate:
+			return ErrInvalidRateDate
+		case err == usecase.ErrNoExchangeRate:
+			return ErrNoExchangeRate
+		default:
+			logrus.WithError(err).Error("failed to export employees")
+			return httpValidationOrInternalErr(err)
+		}
+
+		if !started {
+			if err := start(); err != nil {
+				logrus.Error(err)
+				return nil
+			}
+		}
+
+		if err := writer.Close(); err != nil {
+			logrus.WithError(err).Error("failed to write the export")
+		}
+
+		return nil
+	}
+}
+
+func (s *service) searchEmployeesByQuery(c echo.Context, searchCriteria model.EmployeeSearchCriteria) error {
+	ctx := c.Request().Context()
+
+	results, count, err := s.employeeUsecase.SearchByQuery(ctx, searchCriteria)
+	switch err {
+	case nil:
+		break
+	case usecase.ErrPermissionDenied:
+		return ErrPermissionDenied
+	case usecase.ErrInvalidCurrency:
+		return ErrInvalidCurrency
+	case usecase.ErrInvalidRateDate:
+		return ErrInvalidRateDate
+	case usecase.ErrNoExchangeRate:
+		return ErrNoExchangeRate
+	default:
+		logrus.WithError(err).Error("failed to search employees")
+		return httpValidationOrInternalErr(err)
+	}
+
+	// the score and highlights of a result only depend on the query, which is part of the URL, and on the employee
+	employees := make([]*model.Employee, 0, len(results))
+	for _, result := range results {
+		employees = append(employees, result.Employee)
+	}
+
+	page, limit := int(searchCriteria.Page), int(searchCriteria.Size)
+	etag := employeesETag(employees, canReadSalary(ctx), int64(page), int64(limit), count)
+	setValidators(c, etag, nil)
+	if isNotModified(c.Request(), etag, nil) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	return c.JSON(http.StatusOK, toResourcePaginationResponse(page, limit, count, toEmployeeSearchResultResponses(ctx, results)))
+}
+
+func (s *service) searchEmployeesByCursor(c echo.Context, searchCriteria model.EmployeeSearchCriteria) error {
+	ctx := c.Request().Context()
+
+	employees, nextCursor, count, err := s.employeeUsecase.SearchByCursor(ctx, searchCriteria)
+	switch err {
+	case nil:
+		break
+	case usecase.ErrPermissionDenied:
+		return ErrPermissionDenied
+	case usecase.ErrInvalidCursor:
+		return ErrInvalidCursor
+	case usecase.ErrInvalidSortField:
+		return ErrInvalidSortField
+	case usecase.ErrInvalidCurrency:
+		return ErrInvalidCurrency
+	case usecase.ErrInvalidRateDate:
+		return ErrInvalidRateDate
+	case usecase.ErrNoExchangeRate:
+		return ErrNoExchangeRate
+	default:
+		logrus.WithError(err).Error("failed to retrieve employees")
+		return httpValidationOrInternalErr(err)
+	}
+
+	etag := employeesETag(employees, canReadSalary(ctx), searchCriteria.Size, count)
+	setValidators(c, etag, nil)
+	if isNotModified(c.Request(), etag, nil) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	var countPtr *int64
+	if searchCriteria.WithCount {
+		countPtr = &count
+	}
+
+	return c.JSON(http.StatusOK, toCursorPaginationResponse(int(searchCriteria.Size), countPtr, nextCursor, toEmployeeResponses(ctx, employees)))
+}
+
+// Suggest return the most common names or positions starting with the prefix, with their count
+func (s *service) Suggest() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		limit, err := parseQueryParam(c, "limit", 10)
 		if err != nil {
-			logrus.WithError(err).Error("failed to retrieve employees")
-			return c.JSON(http.StatusBadRequest, "Error retrieving employees")
+			logrus.WithError(err).Error("failed to parse limit")
+			return ErrInvalidArgument
 		}
 
-		logrus.WithFields(logrus.Fields{
-			"page":  page,
-			"limit": limit,
-		}).Info("success retrieving employees")
+		suggestions, err := s.employeeUsecase.Suggest(ctx, model.EmployeeSuggestCriteria{
+			Field:  model.EmployeeSuggestField(c.QueryParam("field")),
+			Prefix: c.QueryParam("prefix"),
+			Limit:  limit,
+		})
+		switch err {
+		case nil:
+			break
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		default:
+			logrus.WithError(err).Error("failed to suggest")
+			return httpValidationOrInternalErr(err)
+		}
 
-		return c.JSON(http.StatusOK, toResourcePaginationResponse(page, limit, count, employees))
+		return c.JSON(http.StatusOK, setSuccessResponse(suggestions))
 	}
 }
 
@@ -117,18 +555,98 @@ func (s *service) Update() echo.HandlerFunc {
 		}
 		employeeID := utils.StringToInt64(c.Param("employee_id"))
 
-		newEmployee, err := s.employeeUsecase.Update(ctx, employeeID, req)
+		expectedVersion, err := s.resolveIfMatch(c, employeeID)
+		if err != nil {
+			return err
+		}
+
+		newEmployee, err := s.employeeUsecase.Update(ctx, employeeID, req, expectedVersion)
 		switch err {
 		case nil:
 			break
 		case usecase.ErrNotFound:
 			return ErrNotFound
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		case usecase.ErrPreconditionFailed:
+			return ErrPreconditionFailed
+		case usecase.ErrConflict:
+			return ErrConflict
+		case usecase.ErrDepartmentNotFound:
+			return ErrDepartmentNotFound
+		case usecase.ErrManagerNotFound:
+			return ErrManagerNotFound
+		case usecase.ErrManagerCycle:
+			return ErrManagerCycle
+		case usecase.ErrPositionNotFound:
+			return ErrPositionNotFound
+		case usecase.ErrSalaryOutOfBand:
+			return ErrSalaryOutOfBand
+		case usecase.ErrNoExchangeRate:
+			return ErrNoExchangeRate
 		default:
 			logrus.Error(err)
-			return ErrInternal
+			return httpValidationOrInternalErr(err)
+		}
+
+		c.Response().Header().Set("ETag", employeeETag(newEmployee, canReadSalary(ctx)))
+		return c.JSON(http.StatusCreated, setSuccessResponse(toEmployeeResponse(ctx, newEmployee)))
+	}
+}
+
+func (s *service) Patch() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		if !isMergePatchContentType(c.Request().Header.Get(echo.HeaderContentType)) {
+			return ErrUnsupportedMediaType
+		}
+
+		body, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+		employeeID := utils.StringToInt64(c.Param("employee_id"))
+
+		expectedVersion, err := s.resolveIfMatch(c, employeeID)
+		if err != nil {
+			return err
 		}
 
-		return c.JSON(http.StatusCreated, setSuccessResponse(newEmployee))
+		employee, err := s.employeeUsecase.Patch(ctx, employeeID, body, expectedVersion)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		case usecase.ErrPreconditionFailed:
+			return ErrPreconditionFailed
+		case usecase.ErrConflict:
+			return ErrConflict
+		case usecase.ErrInvalidPatch:
+			return ErrInvalidPatch
+		case usecase.ErrDepartmentNotFound:
+			return ErrDepartmentNotFound
+		case usecase.ErrManagerNotFound:
+			return ErrManagerNotFound
+		case usecase.ErrManagerCycle:
+			return ErrManagerCycle
+		case usecase.ErrPositionNotFound:
+			return ErrPositionNotFound
+		case usecase.ErrSalaryOutOfBand:
+			return ErrSalaryOutOfBand
+		case usecase.ErrNoExchangeRate:
+			return ErrNoExchangeRate
+		default:
+			logrus.Error(err)
+			return httpValidationOrInternalErr(err)
+		}
+
+		c.Response().Header().Set("ETag", employeeETag(employee, canReadSalary(ctx)))
+		return c.JSON(http.StatusOK, setSuccessResponse(toEmployeeResponse(ctx, employee)))
 	}
 }
 
@@ -137,7 +655,24 @@ func (s *service) Delete() echo.HandlerFunc {
 		ctx := c.Request().Context()
 		employeeID := utils.StringToInt64(c.Param("employee_id"))
 
-		if err := s.employeeUsecase.DeleteByID(ctx, employeeID); err != nil {
+		expectedVersion, err := s.resolveIfMatch(c, employeeID)
+		if err != nil {
+			return err
+		}
+
+		err = s.employeeUsecase.DeleteByID(ctx, employeeID, expectedVersion)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		case usecase.ErrPreconditionFailed:
+			return ErrPreconditionFailed
+		case usecase.ErrConflict:
+			return ErrConflict
+		default:
 			logrus.WithContext(ctx).WithFields(logrus.Fields{
 				"employeeID": employeeID,
 			}).Error(err)
@@ -154,10 +689,91 @@ func (s *service) GetDistinctPositions() echo.HandlerFunc {
 		ctx := c.Request().Context()
 
 		positions, err := s.employeeUsecase.GetDistinctPositions(ctx)
-		if err != nil {
+		switch err {
+		case nil:
+			break
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		default:
 			return c.JSON(http.StatusInternalServerError, err)
 		}
 
 		return c.JSON(http.StatusOK, positions)
 	}
 }
+
+func (s *service) GetHistory() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		employeeID := utils.StringToInt64(c.Param("employee_id"))
+
+		page, err := parseQueryParam(c, "page", 1)
+		if err != nil {
+			logrus.WithError(err).Error("failed to parse page")
+			return ErrInvalidArgument
+		}
+
+		limit, err := parseQueryParam(c, "limit", 10)
+		if err != nil {
+			logrus.WithError(err).Error("failed to parse limit")
+			return ErrInvalidArgument
+		}
+
+		logs, count, err := s.employeeUsecase.FindHistoryByCriteria(ctx, model.EmployeeAuditLogCriteria{
+			EmployeeID: employeeID,
+			Page:       int64(page),
+			Size:       int64(limit),
+		})
+		switch err {
+		case nil:
+			break
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		default:
+			logrus.WithContext(ctx).WithFields(logrus.Fields{
+				"employeeID": employeeID,
+			}).Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, toResourcePaginationResponse(page, limit, count, logs))
+	}
+}
+
+// resolveIfMatch resolve the If-Match header into the version expected by a conditional write,
+// zero means the write is unconditional
+func (s *service) resolveIfMatch(c echo.Context, employeeID int64) (int64, error) {
+	versions, err := parseIfMatch(c.Request().Header.Get("If-Match"))
+	if err != nil {
+		return 0, err
+	}
+
+	switch len(versions) {
+	case 0:
+		return 0, nil
+	case 1:
+		return versions[0], nil
+	}
+
+	// several entity tags are listed, expect the current version if it is one of them
+	employee, err := s.employeeUsecase.FindByID(c.Request().Context(), employeeID)
+	switch err {
+	case nil:
+		break
+	case usecase.ErrNotFound:
+		return 0, ErrNotFound
+	case usecase.ErrPermissionDenied:
+		return 0, ErrPermissionDenied
+	default:
+		logrus.WithField("employeeID", employeeID).Error(err)
+		return 0, ErrInternal
+	}
+
+	for _, version := range versions {
+		if version == employee.Version {
+			return version, nil
+		}
+	}
+
+	return 0, ErrPreconditionFailed
+}
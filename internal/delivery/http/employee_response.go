@@ -0,0 +1,73 @@
+package http
+
+import (
+	"context"
+	"github.com/irvankadhafi/employee-api/internal/auth"
+	"github.com/irvankadhafi/employee-api/internal/model"
+)
+
+// employeeResponse is the employee representation returned to the client,
+// the salary and the reporting salary are omitted when the caller is not allowed to read them
+type employeeResponse struct {
+	*model.Employee
+	Salary            *model.Money `json:"salary,omitempty"`
+	ReportingSalary   *model.Money `json:"reporting_salary,omitempty"`
+	ReportingCurrency string       `json:"reporting_currency,omitempty"`
+}
+
+func toEmployeeResponse(ctx context.Context, employee *model.Employee) *employeeResponse {
+	if employee == nil {
+		return nil
+	}
+
+	return newEmployeeResponse(employee, canReadSalary(ctx))
+}
+
+func toEmployeeResponses(ctx context.Context, employees []*model.Employee) []*employeeResponse {
+	showSalary := canReadSalary(ctx)
+
+	items := make([]*employeeResponse, 0, len(employees))
+	for _, employee := range employees {
+		items = append(items, newEmployeeResponse(employee, showSalary))
+	}
+
+	return items
+}
+
+func newEmployeeResponse(employee *model.Employee, showSalary bool) *employeeResponse {
+	resp := &employeeResponse{Employee: employee}
+	if showSalary {
+		salary := employee.Salary
+		resp.Salary = &salary
+		resp.ReportingSalary = employee.ReportingSalary
+		resp.ReportingCurrency = employee.ReportingCurrency
+	}
+
+	return resp
+}
+
+func canReadSalary(ctx context.Context) bool {
+	return auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionEmployeeSalaryRead)
+}
+
+// employeeSearchResultResponse is an employee of a search ranked by relevance
+type employeeSearchResultResponse struct {
+	*employeeResponse
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights,omitempty"`
+}
+
+func toEmployeeSearchResultResponses(ctx context.Context, results []*model.EmployeeSearchResult) []*employeeSearchResultResponse {
+	showSalary := canReadSalary(ctx)
+
+	items := make([]*employeeSearchResultResponse, 0, len(results))
+	for _, result := range results {
+		items = append(items, &employeeSearchResultResponse{
+			employeeResponse: newEmployeeResponse(result.Employee, showSalary),
+			Score:            result.Score,
+			Highlights:       result.Highlights,
+		})
+	}
+
+	return items
+}
@@ -3,15 +3,47 @@ package http
 import (
 	"fmt"
 	"github.com/go-playground/validator/v10"
+	"github.com/irvankadhafi/employee-api/internal/config"
 	"github.com/labstack/echo/v4"
 	"net/http"
 )
 
 var (
-	ErrInvalidArgument      = echo.NewHTTPError(http.StatusBadRequest, setErrorMessage("invalid argument"))
-	ErrInternal             = echo.NewHTTPError(http.StatusInternalServerError, setErrorMessage("internal system error"))
-	ErrNotFound             = echo.NewHTTPError(http.StatusNotFound, setErrorMessage("record not found"))
-	ErrEmployeeAlreadyExist = echo.NewHTTPError(http.StatusBadRequest, setErrorMessage("employee already exist"))
+	ErrInvalidArgument        = echo.NewHTTPError(http.StatusBadRequest, setErrorMessage("invalid argument"))
+	ErrInternal               = echo.NewHTTPError(http.StatusInternalServerError, setErrorMessage("internal system error"))
+	ErrNotFound               = echo.NewHTTPError(http.StatusNotFound, setErrorMessage("record not found"))
+	ErrEmployeeAlreadyExist   = echo.NewHTTPError(http.StatusBadRequest, setErrorMessage("employee already exist"))
+	ErrUnauthorized           = echo.NewHTTPError(http.StatusUnauthorized, setErrorMessage("unauthorized"))
+	ErrPermissionDenied       = echo.NewHTTPError(http.StatusForbidden, setErrorMessage("permission denied"))
+	ErrPreconditionFailed     = echo.NewHTTPError(http.StatusPreconditionFailed, setErrorMessage("precondition failed, the employee has been modified"))
+	ErrConflict               = echo.NewHTTPError(http.StatusConflict, setErrorMessage("the employee was modified concurrently, please retry"))
+	ErrEmptyItems             = echo.NewHTTPError(http.StatusBadRequest, setErrorMessage("items must not be empty"))
+	ErrTooManyItems           = echo.NewHTTPError(http.StatusRequestEntityTooLarge, setErrorMessage("too many items"))
+	ErrInvalidCSVHeader       = echo.NewHTTPError(http.StatusBadRequest, setErrorMessage("invalid csv header, the name, position and salary columns are required"))
+	ErrInvalidCursor          = echo.NewHTTPError(http.StatusBadRequest, setErrorMessage("invalid cursor, it must be the next_cursor of a search with the same sort"))
+	ErrInvalidSortField       = echo.NewHTTPError(http.StatusBadRequest, setErrorMessage("invalid sort, use a comma separated list of id, name, position, salary, created_at or updated_at, each prefixed with - for a descending order"))
+	ErrInvalidPatch           = echo.NewHTTPError(http.StatusBadRequest, setErrorMessage("invalid merge patch document"))
+	ErrUnsupportedMediaType   = echo.NewHTTPError(http.StatusUnsupportedMediaType, setErrorMessage("unsupported media type, use application/merge-patch+json"))
+	ErrDepartmentAlreadyExist = echo.NewHTTPError(http.StatusBadRequest, setErrorMessage("department already exist"))
+	ErrDepartmentHasMembers   = echo.NewHTTPError(http.StatusConflict, setErrorMessage("the department still has employees, move them to another department first"))
+	ErrDepartmentNotFound     = echo.NewHTTPError(http.StatusBadRequest, setErrorMessage("department not found"))
+	ErrManagerNotFound        = echo.NewHTTPError(http.StatusBadRequest, setErrorMessage("manager not found"))
+	ErrManagerCycle           = echo.NewHTTPError(http.StatusBadRequest, setErrorMessage("the employee can't report to itself or to one of its reports"))
+	ErrPositionAlreadyExist   = echo.NewHTTPError(http.StatusBadRequest, setErrorMessage("position already exist"))
+	ErrPositionInUse          = echo.NewHTTPError(http.StatusConflict, setErrorMessage("the position is still held by employees, move them to another position first"))
+	ErrPositionNotFound       = echo.NewHTTPError(http.StatusBadRequest, setErrorMessage("position not found in the positions catalog"))
+	ErrSalaryOutOfBand        = echo.NewHTTPError(http.StatusBadRequest, setErrorMessage("salary is out of the band of the position"))
+	ErrInvalidPeriod          = echo.NewHTTPError(http.StatusBadRequest, setErrorMessage("invalid period, use the YYYY-MM format"))
+	ErrNoRateTable            = echo.NewHTTPError(http.StatusBadRequest, setErrorMessage("no payroll rate table is effective for the period"))
+	ErrNoSalaryForPeriod      = echo.NewHTTPError(http.StatusBadRequest, setErrorMessage("the employee has no salary in the period"))
+	ErrPayrollRunInProgress   = echo.NewHTTPError(http.StatusConflict, setErrorMessage("a payroll run of the period is already in progress, approve or delete it first"))
+	ErrPayrollRunApproved     = echo.NewHTTPError(http.StatusConflict, setErrorMessage("the payroll run of the period is already approved, a period is run once"))
+	ErrPayrollRunReadOnly     = echo.NewHTTPError(http.StatusConflict, setErrorMessage("the payroll run is approved, it is read-only"))
+	ErrInvalidTransition      = echo.NewHTTPError(http.StatusConflict, setErrorMessage("the payroll run can't move to this status from its current one"))
+	ErrInvalidCurrency        = echo.NewHTTPError(http.StatusBadRequest, setErrorMessage("invalid currency, use an ISO 4217 code like IDR, USD or SGD"))
+	ErrInvalidRateDate        = echo.NewHTTPError(http.StatusBadRequest, setErrorMessage("invalid rate_date, use the YYYY-MM-DD format"))
+	ErrNoExchangeRate         = echo.NewHTTPError(http.StatusBadRequest, setErrorMessage("no exchange rate is effective on the day for one of the currencies"))
+	ErrInvalidRatesCSV        = echo.NewHTTPError(http.StatusBadRequest, setErrorMessage("invalid csv header, the currency and rate columns are required"))
 )
 
 // httpValidationOrInternalErr return valdiation or internal error
@@ -30,3 +62,13 @@ func httpValidationOrInternalErr(err error) error {
 
 	return echo.NewHTTPError(http.StatusBadRequest, setErrorMessage(fields))
 }
+
+// newInvalidDepthErr return the range of the depth query param, the maximum is configurable
+func newInvalidDepthErr() error {
+	return echo.NewHTTPError(http.StatusBadRequest, setErrorMessage(fmt.Sprintf("invalid depth, it must be between 1 and %d", config.OrgChartMaxDepth())))
+}
+
+// newInvalidFilterErr return the reason a filter query param is rejected
+func newInvalidFilterErr(err error) error {
+	return echo.NewHTTPError(http.StatusBadRequest, setErrorMessage(err.Error()))
+}
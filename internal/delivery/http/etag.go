@@ -0,0 +1,138 @@
+package http
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// restrictedETagSuffix marks the entity tag of a representation without the salary,
+// so it never matches the tag of the full representation
+const restrictedETagSuffix = "-r"
+
+// employeeETag is the strong entity tag of the employee representation,
+// it is derived from the version and the last update time, e.g. "3-1729501200000000000"
+func employeeETag(employee *model.Employee, showSalary bool) string {
+	var updatedAt int64
+	if employee.UpdatedAt != nil {
+		updatedAt = employee.UpdatedAt.UnixNano()
+	}
+
+	tag := fmt.Sprintf("%d-%d", employee.Version, updatedAt)
+	if !showSalary {
+		tag += restrictedETagSuffix
+	}
+
+	return strconv.Quote(tag)
+}
+
+// employeesETag is the strong entity tag of a page of employees,
+// it is derived from the listed IDs with their versions and reporting salaries, and the pagination
+func employeesETag(employees []*model.Employee, showSalary bool, meta ...int64) string {
+	hash := sha1.New()
+	for _, employee := range employees {
+		_, _ = fmt.Fprintf(hash, "%d:%d,", employee.ID, employee.Version)
+		// the reporting salary moves with the exchange rates, without a new version of the employee
+		if employee.ReportingSalary != nil {
+			_, _ = fmt.Fprintf(hash, "%d%s,", *employee.ReportingSalary, employee.ReportingCurrency)
+		}
+	}
+	for _, m := range meta {
+		_, _ = fmt.Fprintf(hash, "%d;", m)
+	}
+
+	tag := hex.EncodeToString(hash.Sum(nil))
+	if !showSalary {
+		tag += restrictedETagSuffix
+	}
+
+	return strconv.Quote(tag)
+}
+
+// parseEmployeeETag return the employee version of a strong entity tag, weak tags never match
+func parseEmployeeETag(tag string) (int64, bool) {
+	if len(tag) < 2 || !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) {
+		return 0, false
+	}
+
+	versionStr, _, _ := strings.Cut(tag[1:len(tag)-1], "-")
+	version, err := strconv.ParseInt(versionStr, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+
+	return version, true
+}
+
+// parseIfMatch return the employee versions listed in the If-Match header.
+// An empty header or "*" returns no version, meaning any current version is accepted.
+// ErrPreconditionFailed is returned when none of the listed entity tags can ever match.
+func parseIfMatch(header string) ([]int64, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+
+	var versions []int64
+	for _, tag := range strings.Split(header, ",") {
+		if version, ok := parseEmployeeETag(strings.TrimSpace(tag)); ok {
+			versions = append(versions, version)
+		}
+	}
+
+	if len(versions) == 0 {
+		return nil, ErrPreconditionFailed
+	}
+
+	return versions, nil
+}
+
+// setValidators set the ETag and Last-Modified headers of a response, lastModified is optional
+func setValidators(c echo.Context, etag string, lastModified *time.Time) {
+	header := c.Response().Header()
+	header.Set("ETag", etag)
+	if lastModified != nil {
+		header.Set(echo.HeaderLastModified, lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	// the representation depends on the caller, so it must be revalidated and never shared
+	header.Set("Cache-Control", "private, no-cache")
+	header.Add(echo.HeaderVary, echo.HeaderAuthorization)
+}
+
+// isNotModified evaluate the If-None-Match and If-Modified-Since headers of a GET request,
+// If-Modified-Since is ignored when If-None-Match is present
+func isNotModified(r *http.Request, etag string, lastModified *time.Time) bool {
+	if ifNoneMatch := strings.TrimSpace(r.Header.Get("If-None-Match")); ifNoneMatch != "" {
+		if ifNoneMatch == "*" {
+			return true
+		}
+
+		for _, tag := range strings.Split(ifNoneMatch, ",") {
+			// If-None-Match uses the weak comparison
+			if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	ifModifiedSince := r.Header.Get(echo.HeaderIfModifiedSince)
+	if ifModifiedSince == "" || lastModified == nil {
+		return false
+	}
+
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+
+	// Last-Modified has a second precision
+	return !lastModified.Truncate(time.Second).After(since)
+}
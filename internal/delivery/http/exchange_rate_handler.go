@@ -0,0 +1,138 @@
+package http
+
+import (
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/irvankadhafi/employee-api/internal/usecase"
+	"github.com/irvankadhafi/employee-api/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+// CreateExchangeRate record the rate of a currency, it replaces the rate of the currency effective on the same day
+func (s *service) CreateExchangeRate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		req := model.CreateExchangeRateRequest{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		rate, err := s.exchangeRateUsecase.Create(ctx, req)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		default:
+			logrus.Error(err)
+			return httpValidationOrInternalErr(err)
+		}
+
+		return c.JSON(http.StatusCreated, setSuccessResponse(rate))
+	}
+}
+
+// SearchExchangeRates list the rates by currency then the latest effective first, currency filters a single currency
+func (s *service) SearchExchangeRates() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		page, err := parseQueryParam(c, "page", 1)
+		if err != nil {
+			logrus.WithError(err).Error("failed to parse page")
+			return ErrInvalidArgument
+		}
+
+		limit, err := parseQueryParam(c, "limit", 10)
+		if err != nil {
+			logrus.WithError(err).Error("failed to parse limit")
+			return ErrInvalidArgument
+		}
+
+		criteria := model.ExchangeRateCriteria{
+			Currency: c.QueryParam("currency"),
+			Page:     int64(page),
+			Size:     int64(limit),
+		}
+
+		rates, count, err := s.exchangeRateUsecase.FindAllByCriteria(ctx, criteria)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		default:
+			logrus.WithError(err).Error("failed to retrieve exchange rates")
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, toResourcePaginationResponse(page, limit, count, rates))
+	}
+}
+
+// DeleteExchangeRate delete a rate, the previous rate of the currency is effective again from its day
+func (s *service) DeleteExchangeRate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		rateID := utils.StringToInt64(c.Param("rate_id"))
+
+		err := s.exchangeRateUsecase.DeleteByID(ctx, rateID)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		default:
+			logrus.WithField("rate_id", rateID).Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, setSuccessResponse(rateID))
+	}
+}
+
+// ImportExchangeRates record the rates of the uploaded CSV file, it is refused with 422 and the row errors
+// when a row is invalid, no rate is saved then
+func (s *service) ImportExchangeRates() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			logrus.WithError(err).Error("failed to read the uploaded file")
+			return ErrInvalidArgument
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			logrus.Error(err)
+			return ErrInternal
+		}
+		defer utils.WrapCloser(file.Close)
+
+		result, err := s.exchangeRateUsecase.Import(ctx, file)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		case usecase.ErrInvalidRatesCSV:
+			return ErrInvalidRatesCSV
+		case usecase.ErrImportRejected:
+			return c.JSON(http.StatusUnprocessableEntity, errorResponse{
+				Success: false,
+				Message: result,
+			})
+		default:
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, setSuccessResponse(result))
+	}
+}
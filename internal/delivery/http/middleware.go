@@ -0,0 +1,32 @@
+package http
+
+import (
+	"github.com/irvankadhafi/employee-api/internal/auth"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// AuthMiddleware verify the bearer token of the request and set the authenticated user to the request context
+func AuthMiddleware(verifier auth.TokenVerifier) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			token, err := auth.ParseBearerToken(c.Request().Header.Get(echo.HeaderAuthorization))
+			if err != nil {
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer realm="api"`)
+				return ErrUnauthorized
+			}
+
+			user, err := verifier.Verify(token)
+			if err != nil {
+				logrus.WithField("remote_ip", c.RealIP()).Warn(err)
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer realm="api", error="invalid_token"`)
+				return ErrUnauthorized
+			}
+
+			ctx := auth.SetUserToCtx(c.Request().Context(), user)
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package http
+
+import (
+	"github.com/irvankadhafi/employee-api/internal/config"
+	"github.com/irvankadhafi/employee-api/internal/usecase"
+	"github.com/irvankadhafi/employee-api/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+// GetReports return the employee with the tree of its reports, depth is the number of levels below the
+// employee, 1 by default for the direct reports only
+func (s *service) GetReports() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		employeeID := utils.StringToInt64(c.Param("employee_id"))
+
+		depth, err := parseQueryParam(c, "depth", 1)
+		if err != nil {
+			logrus.WithError(err).Error("failed to parse depth")
+			return newInvalidDepthErr()
+		}
+
+		node, err := s.employeeUsecase.FindReports(ctx, employeeID, depth)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		case usecase.ErrInvalidDepth:
+			return newInvalidDepthErr()
+		default:
+			logrus.WithField("employee_id", employeeID).Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, setSuccessResponse(node))
+	}
+}
+
+// GetOrgChart return the reporting trees of the company, one per employee without a manager,
+// down to the depth levels, the configured maximum by default
+func (s *service) GetOrgChart() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		depth, err := parseQueryParam(c, "depth", config.OrgChartMaxDepth())
+		if err != nil {
+			logrus.WithError(err).Error("failed to parse depth")
+			return newInvalidDepthErr()
+		}
+
+		nodes, err := s.employeeUsecase.GetOrgChart(ctx, depth)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		case usecase.ErrInvalidDepth:
+			return newInvalidDepthErr()
+		default:
+			logrus.WithError(err).Error("failed to retrieve the org chart")
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, setSuccessResponse(nodes))
+	}
+}
@@ -0,0 +1,136 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/irvankadhafi/employee-api/internal/config"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/irvankadhafi/employee-api/internal/payroll"
+	"github.com/irvankadhafi/employee-api/internal/usecase"
+	"github.com/irvankadhafi/employee-api/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+// GetPayslip return the gross-to-net payslip of the employee for the period query param, YYYY-MM
+func (s *service) GetPayslip() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		employeeID := utils.StringToInt64(c.Param("employee_id"))
+
+		payslip, err := s.payrollUsecase.GetPayslip(ctx, employeeID, c.QueryParam("period"))
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		case usecase.ErrInvalidPeriod:
+			return ErrInvalidPeriod
+		case usecase.ErrNoRateTable:
+			return ErrNoRateTable
+		case usecase.ErrNoExchangeRate:
+			return ErrNoExchangeRate
+		case usecase.ErrNoSalaryForPeriod:
+			return ErrNoSalaryForPeriod
+		default:
+			logrus.WithField("employee_id", employeeID).Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, setSuccessResponse(payslip))
+	}
+}
+
+// GetPayslipPDF return the payslip of the employee for the period param, YYYY-MM, as a PDF attachment
+func (s *service) GetPayslipPDF() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		employeeID := utils.StringToInt64(c.Param("employee_id"))
+		period := c.Param("period")
+
+		payslip, err := s.payrollUsecase.GetPayslip(ctx, employeeID, period)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		case usecase.ErrInvalidPeriod:
+			return ErrInvalidPeriod
+		case usecase.ErrNoRateTable:
+			return ErrNoRateTable
+		case usecase.ErrNoExchangeRate:
+			return ErrNoExchangeRate
+		case usecase.ErrNoSalaryForPeriod:
+			return ErrNoSalaryForPeriod
+		default:
+			logrus.WithField("employee_id", employeeID).Error(err)
+			return ErrInternal
+		}
+
+		// rendered in memory first, so that a failure is still returned as JSON
+		buf := &bytes.Buffer{}
+		if err := payroll.WritePayslipPDF(buf, payslip, config.PayslipCompanyName()); err != nil {
+			logrus.WithField("employee_id", employeeID).Error(err)
+			return ErrInternal
+		}
+
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, payroll.PayslipFileName(payslip)))
+		return c.Blob(http.StatusOK, "application/pdf", buf.Bytes())
+	}
+}
+
+func (s *service) GetPayrollProfile() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		employeeID := utils.StringToInt64(c.Param("employee_id"))
+
+		profile, err := s.payrollUsecase.FindProfile(ctx, employeeID)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		default:
+			logrus.WithField("employee_id", employeeID).Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, setSuccessResponse(profile))
+	}
+}
+
+// UpdatePayrollProfile replace the PTKP status, the allowances and the deductions of the employee
+func (s *service) UpdatePayrollProfile() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		employeeID := utils.StringToInt64(c.Param("employee_id"))
+
+		req := model.UpdatePayrollProfileRequest{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		profile, err := s.payrollUsecase.UpdateProfile(ctx, employeeID, req)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		default:
+			logrus.WithField("employee_id", employeeID).Error(err)
+			return httpValidationOrInternalErr(err)
+		}
+
+		return c.JSON(http.StatusOK, setSuccessResponse(profile))
+	}
+}
@@ -0,0 +1,205 @@
+package http
+
+import (
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/irvankadhafi/employee-api/internal/usecase"
+	"github.com/irvankadhafi/employee-api/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+// CreatePayrollRun create a draft payroll run of the period of the body, snapshotting the payslips of every active employee
+func (s *service) CreatePayrollRun() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		req := struct {
+			Period string `json:"period"`
+		}{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		run, err := s.payrollUsecase.CreateRun(ctx, req.Period)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		case usecase.ErrInvalidPeriod:
+			return ErrInvalidPeriod
+		case usecase.ErrNoRateTable:
+			return ErrNoRateTable
+		case usecase.ErrNoExchangeRate:
+			return ErrNoExchangeRate
+		case usecase.ErrPayrollRunInProgress:
+			return ErrPayrollRunInProgress
+		case usecase.ErrPayrollRunApproved:
+			return ErrPayrollRunApproved
+		default:
+			logrus.WithField("period", req.Period).Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusCreated, setSuccessResponse(run))
+	}
+}
+
+// SearchPayrollRuns list the payroll runs, filtered by the period and status query params
+func (s *service) SearchPayrollRuns() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		page, err := parseQueryParam(c, "page", 1)
+		if err != nil {
+			logrus.WithError(err).Error("failed to parse page")
+			return ErrInvalidArgument
+		}
+
+		limit, err := parseQueryParam(c, "limit", 10)
+		if err != nil {
+			logrus.WithError(err).Error("failed to parse limit")
+			return ErrInvalidArgument
+		}
+
+		criteria := model.PayrollRunCriteria{
+			Period: c.QueryParam("period"),
+			Status: model.PayrollRunStatus(c.QueryParam("status")),
+			Page:   int64(page),
+			Size:   int64(limit),
+		}
+
+		runs, count, err := s.payrollUsecase.FindRunsByCriteria(ctx, criteria)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		case usecase.ErrInvalidPeriod:
+			return ErrInvalidPeriod
+		default:
+			logrus.WithError(err).Error("failed to retrieve payroll runs")
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, toResourcePaginationResponse(page, limit, count, runs))
+	}
+}
+
+func (s *service) GetPayrollRun() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		runID := utils.StringToInt64(c.Param("run_id"))
+
+		run, err := s.payrollUsecase.FindRunByID(ctx, runID)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		default:
+			logrus.WithField("run_id", runID).Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, setSuccessResponse(run))
+	}
+}
+
+// GetPayrollRunItems list the payslips snapshot by the payroll run
+func (s *service) GetPayrollRunItems() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		runID := utils.StringToInt64(c.Param("run_id"))
+
+		page, err := parseQueryParam(c, "page", 1)
+		if err != nil {
+			logrus.WithError(err).Error("failed to parse page")
+			return ErrInvalidArgument
+		}
+
+		limit, err := parseQueryParam(c, "limit", 10)
+		if err != nil {
+			logrus.WithError(err).Error("failed to parse limit")
+			return ErrInvalidArgument
+		}
+
+		criteria := model.PayrollRunItemCriteria{
+			PayrollRunID: runID,
+			Page:         int64(page),
+			Size:         int64(limit),
+		}
+
+		items, count, err := s.payrollUsecase.FindRunItemsByCriteria(ctx, criteria)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		default:
+			logrus.WithField("run_id", runID).Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, toResourcePaginationResponse(page, limit, count, items))
+	}
+}
+
+// MovePayrollRun move the payroll run to the status, it must be the next one of its current status
+func (s *service) MovePayrollRun(status model.PayrollRunStatus) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		runID := utils.StringToInt64(c.Param("run_id"))
+
+		run, err := s.payrollUsecase.MoveRun(ctx, runID, status)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		case usecase.ErrInvalidTransition:
+			return ErrInvalidTransition
+		default:
+			logrus.WithFields(logrus.Fields{
+				"run_id": runID,
+				"status": status,
+			}).Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, setSuccessResponse(run))
+	}
+}
+
+// DeletePayrollRun delete a draft or reviewed payroll run, so that the period can be run again
+func (s *service) DeletePayrollRun() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		runID := utils.StringToInt64(c.Param("run_id"))
+
+		err := s.payrollUsecase.DeleteRun(ctx, runID)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		case usecase.ErrPayrollRunReadOnly:
+			return ErrPayrollRunReadOnly
+		default:
+			logrus.WithField("run_id", runID).Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, setSuccessResponse(runID))
+	}
+}
@@ -0,0 +1,162 @@
+package http
+
+import (
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/irvankadhafi/employee-api/internal/usecase"
+	"github.com/irvankadhafi/employee-api/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+func (s *service) CreatePosition() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		req := model.CreatePositionRequest{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		position, err := s.positionUsecase.Create(ctx, req)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrDuplicatePosition:
+			return ErrPositionAlreadyExist
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		default:
+			logrus.Error(err)
+			return httpValidationOrInternalErr(err)
+		}
+
+		return c.JSON(http.StatusCreated, setSuccessResponse(toPositionResponse(ctx, position)))
+	}
+}
+
+func (s *service) GetPositionDetail() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		positionID := utils.StringToInt64(c.Param("position_id"))
+
+		position, err := s.positionUsecase.FindByID(ctx, positionID)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		default:
+			logrus.WithField("position_id", positionID).Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, setSuccessResponse(toPositionResponse(ctx, position)))
+	}
+}
+
+// SearchPositions list the catalog ordered by level then title, q matches the code or the title
+func (s *service) SearchPositions() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		page, err := parseQueryParam(c, "page", 1)
+		if err != nil {
+			logrus.WithError(err).Error("failed to parse page")
+			return ErrInvalidArgument
+		}
+
+		limit, err := parseQueryParam(c, "limit", 10)
+		if err != nil {
+			logrus.WithError(err).Error("failed to parse limit")
+			return ErrInvalidArgument
+		}
+
+		level, err := parseQueryParam(c, "level", 0)
+		if err != nil {
+			logrus.WithError(err).Error("failed to parse level")
+			return ErrInvalidArgument
+		}
+
+		criteria := model.PositionCriteria{
+			Query: c.QueryParam("q"),
+			Level: level,
+			Page:  int64(page),
+			Size:  int64(limit),
+		}
+
+		positions, count, err := s.positionUsecase.FindAllByCriteria(ctx, criteria)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		default:
+			logrus.WithError(err).Error("failed to retrieve positions")
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, toResourcePaginationResponse(page, limit, count, toPositionResponses(ctx, positions)))
+	}
+}
+
+// UpdatePosition replace the position, renaming it is refused with 409 while employees hold it
+func (s *service) UpdatePosition() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		req := model.UpdatePositionRequest{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+		positionID := utils.StringToInt64(c.Param("position_id"))
+
+		position, err := s.positionUsecase.Update(ctx, positionID, req)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		case usecase.ErrDuplicatePosition:
+			return ErrPositionAlreadyExist
+		case usecase.ErrPositionInUse:
+			return ErrPositionInUse
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		default:
+			logrus.Error(err)
+			return httpValidationOrInternalErr(err)
+		}
+
+		return c.JSON(http.StatusOK, setSuccessResponse(toPositionResponse(ctx, position)))
+	}
+}
+
+// DeletePosition delete a position nobody holds, it is refused with 409 otherwise
+func (s *service) DeletePosition() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		positionID := utils.StringToInt64(c.Param("position_id"))
+
+		err := s.positionUsecase.DeleteByID(ctx, positionID)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		case usecase.ErrPositionInUse:
+			return ErrPositionInUse
+		default:
+			logrus.WithField("position_id", positionID).Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, setSuccessResponse(positionID))
+	}
+}
@@ -0,0 +1,44 @@
+package http
+
+import (
+	"context"
+	"github.com/irvankadhafi/employee-api/internal/model"
+)
+
+// positionResponse is the position representation returned to the client,
+// the salary band is omitted when the caller is not allowed to read salaries
+type positionResponse struct {
+	*model.Position
+	MinSalary *model.Money `json:"min_salary,omitempty"`
+	MaxSalary *model.Money `json:"max_salary,omitempty"`
+}
+
+func toPositionResponse(ctx context.Context, position *model.Position) *positionResponse {
+	if position == nil {
+		return nil
+	}
+
+	return newPositionResponse(position, canReadSalary(ctx))
+}
+
+func toPositionResponses(ctx context.Context, positions []*model.Position) []*positionResponse {
+	showSalary := canReadSalary(ctx)
+
+	items := make([]*positionResponse, 0, len(positions))
+	for _, position := range positions {
+		items = append(items, newPositionResponse(position, showSalary))
+	}
+
+	return items
+}
+
+func newPositionResponse(position *model.Position, showSalary bool) *positionResponse {
+	resp := &positionResponse{Position: position}
+	if showSalary {
+		minSalary, maxSalary := position.MinSalary, position.MaxSalary
+		resp.MinSalary = &minSalary
+		resp.MaxSalary = &maxSalary
+	}
+
+	return resp
+}
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/irvankadhafi/employee-api/internal/usecase"
+	"github.com/irvankadhafi/employee-api/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+// GetSalaryHistory page through the salary changes of the employee, the latest effective first
+func (s *service) GetSalaryHistory() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		employeeID := utils.StringToInt64(c.Param("employee_id"))
+
+		page, err := parseQueryParam(c, "page", 1)
+		if err != nil {
+			logrus.WithError(err).Error("failed to parse page")
+			return ErrInvalidArgument
+		}
+
+		limit, err := parseQueryParam(c, "limit", 10)
+		if err != nil {
+			logrus.WithError(err).Error("failed to parse limit")
+			return ErrInvalidArgument
+		}
+
+		changes, count, err := s.employeeUsecase.FindSalaryHistory(ctx, model.SalaryChangeCriteria{
+			EmployeeID: employeeID,
+			Page:       int64(page),
+			Size:       int64(limit),
+		})
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		default:
+			logrus.WithField("employee_id", employeeID).Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, toResourcePaginationResponse(page, limit, count, changes))
+	}
+}
+
+// CreateSalaryChange record a salary change of the employee, effective today unless effective_from is given
+func (s *service) CreateSalaryChange() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		employeeID := utils.StringToInt64(c.Param("employee_id"))
+
+		req := model.CreateSalaryChangeRequest{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		change, err := s.employeeUsecase.CreateSalaryChange(ctx, employeeID, req)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		case usecase.ErrPositionNotFound:
+			return ErrPositionNotFound
+		case usecase.ErrSalaryOutOfBand:
+			return ErrSalaryOutOfBand
+		case usecase.ErrNoExchangeRate:
+			return ErrNoExchangeRate
+		default:
+			logrus.WithField("employee_id", employeeID).Error(err)
+			return httpValidationOrInternalErr(err)
+		}
+
+		return c.JSON(http.StatusCreated, setSuccessResponse(change))
+	}
+}
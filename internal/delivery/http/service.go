@@ -7,30 +7,95 @@ import (
 
 // service http service
 type service struct {
-	employeeUsecase model.EmployeeUsecase
+	employeeUsecase     model.EmployeeUsecase
+	departmentUsecase   model.DepartmentUsecase
+	positionUsecase     model.PositionUsecase
+	payrollUsecase      model.PayrollUsecase
+	exchangeRateUsecase model.ExchangeRateUsecase
 }
 
 // RouteService ..
 func RouteService(
 	group *echo.Group,
 	employeeUsecase model.EmployeeUsecase,
+	departmentUsecase model.DepartmentUsecase,
+	positionUsecase model.PositionUsecase,
+	payrollUsecase model.PayrollUsecase,
+	exchangeRateUsecase model.ExchangeRateUsecase,
 ) {
 	svc := &service{
-		employeeUsecase: employeeUsecase,
+		employeeUsecase:     employeeUsecase,
+		departmentUsecase:   departmentUsecase,
+		positionUsecase:     positionUsecase,
+		payrollUsecase:      payrollUsecase,
+		exchangeRateUsecase: exchangeRateUsecase,
 	}
 
 	svc.initRoutes(group)
 }
 
 func (s *service) initRoutes(group *echo.Group) {
-	group.GET("/positions/", s.GetDistinctPositions())
+	group.GET("/org-chart/", s.GetOrgChart())
 
 	employeeRoute := group.Group("/employees")
 	{
 		employeeRoute.POST("/", s.Create())
+		employeeRoute.POST("/bulk/", s.BulkCreate())
+		employeeRoute.POST("/import/", s.Import())
+		employeeRoute.GET("/export/", s.Export())
+		employeeRoute.POST("/search/", s.SearchEmployeesByFilter())
+		employeeRoute.GET("/suggest/", s.Suggest())
+		employeeRoute.GET("/positions/", s.GetDistinctPositions())
 		employeeRoute.GET("/:employee_id/", s.GetDetail())
 		employeeRoute.GET("/", s.SearchEmployees())
 		employeeRoute.PUT("/:employee_id/", s.Update())
+		employeeRoute.PATCH("/:employee_id/", s.Patch())
 		employeeRoute.DELETE("/:employee_id/", s.Delete())
+		employeeRoute.GET("/:employee_id/history/", s.GetHistory())
+		employeeRoute.GET("/:employee_id/reports/", s.GetReports())
+		employeeRoute.GET("/:employee_id/salary-history/", s.GetSalaryHistory())
+		employeeRoute.POST("/:employee_id/salary-history/", s.CreateSalaryChange())
+		employeeRoute.GET("/:employee_id/payslip/", s.GetPayslip())
+		employeeRoute.GET("/:employee_id/payslips/:period/pdf/", s.GetPayslipPDF())
+		employeeRoute.GET("/:employee_id/payroll-profile/", s.GetPayrollProfile())
+		employeeRoute.PUT("/:employee_id/payroll-profile/", s.UpdatePayrollProfile())
+	}
+
+	departmentRoute := group.Group("/departments")
+	{
+		departmentRoute.POST("/", s.CreateDepartment())
+		departmentRoute.GET("/", s.SearchDepartments())
+		departmentRoute.GET("/:department_id/", s.GetDepartmentDetail())
+		departmentRoute.PUT("/:department_id/", s.UpdateDepartment())
+		departmentRoute.DELETE("/:department_id/", s.DeleteDepartment())
+	}
+
+	positionRoute := group.Group("/positions")
+	{
+		positionRoute.POST("/", s.CreatePosition())
+		positionRoute.GET("/", s.SearchPositions())
+		positionRoute.GET("/:position_id/", s.GetPositionDetail())
+		positionRoute.PUT("/:position_id/", s.UpdatePosition())
+		positionRoute.DELETE("/:position_id/", s.DeletePosition())
+	}
+
+	payrollRoute := group.Group("/payroll")
+	{
+		payrollRoute.POST("/runs/", s.CreatePayrollRun())
+		payrollRoute.GET("/runs/", s.SearchPayrollRuns())
+		payrollRoute.GET("/runs/:run_id/", s.GetPayrollRun())
+		payrollRoute.DELETE("/runs/:run_id/", s.DeletePayrollRun())
+		payrollRoute.GET("/runs/:run_id/items/", s.GetPayrollRunItems())
+		payrollRoute.POST("/runs/:run_id/review/", s.MovePayrollRun(model.PayrollRunStatusReviewed))
+		payrollRoute.POST("/runs/:run_id/approve/", s.MovePayrollRun(model.PayrollRunStatusApproved))
+		payrollRoute.POST("/runs/:run_id/pay/", s.MovePayrollRun(model.PayrollRunStatusPaid))
+	}
+
+	exchangeRateRoute := group.Group("/exchange-rates")
+	{
+		exchangeRateRoute.POST("/", s.CreateExchangeRate())
+		exchangeRateRoute.GET("/", s.SearchExchangeRates())
+		exchangeRateRoute.POST("/import/", s.ImportExchangeRates())
+		exchangeRateRoute.DELETE("/:rate_id/", s.DeleteExchangeRate())
 	}
 }
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"time"
+)
+
+type DepartmentUsecase interface {
+	Create(ctx context.Context, input CreateDepartmentRequest) (department *Department, err error)
+	FindByID(ctx context.Context, id int64) (department *Department, err error)
+	FindAllByCriteria(ctx context.Context, criteria DepartmentCriteria) (departments []*Department, count int64, err error)
+	Update(ctx context.Context, id int64, input UpdateDepartmentRequest) (department *Department, err error)
+	DeleteByID(ctx context.Context, id int64) (err error)
+}
+
+type DepartmentRepository interface {
+	Create(ctx context.Context, department *Department) error
+	FindByID(ctx context.Context, id int64) (*Department, error)
+	FindByName(ctx context.Context, name string) (*Department, error)
+	FindAllByCriteria(ctx context.Context, criteria DepartmentCriteria) (departments []*Department, count int64, err error)
+	Update(ctx context.Context, department *Department) error
+	Delete(ctx context.Context, id int64) error
+}
+
+// Department :nodoc:
+type Department struct {
+	ID          int64          `json:"id" gorm:"<-:create; primary_key;AUTO_INCREMENT"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	CreatedAt   *time.Time     `json:"created_at" gorm:"->;<-:create"`
+	UpdatedAt   *time.Time     `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"deleted_at"`
+}
+
+// CreateDepartmentRequest DTO for creating a new department
+type CreateDepartmentRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description" validate:"max=500"`
+}
+
+func (c *CreateDepartmentRequest) Validate() error {
+	return validate.Struct(c)
+}
+
+// UpdateDepartmentRequest DTO for replacing a department, every field is overwritten
+type UpdateDepartmentRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description" validate:"max=500"`
+}
+
+func (c *UpdateDepartmentRequest) Validate() error {
+	return validate.Struct(c)
+}
+
+// DepartmentCriteria :nodoc:
+type DepartmentCriteria struct {
+	Name string `json:"name"`
+	Page int64  `json:"page"`
+	Size int64  `json:"size"`
+}
+
+// SetDefaultValue will set default value for page and size if zero
+func (c *DepartmentCriteria) SetDefaultValue() {
+	if c.Page <= 0 {
+		c.Page = 1
+	}
+	if c.Size <= 0 {
+		c.Size = 10
+	}
+}
@@ -2,79 +2,207 @@ package model
 
 import (
 	"context"
+	"github.com/irvankadhafi/employee-api/internal/config"
 	"gorm.io/gorm"
+	"io"
 	"time"
 )
 
 type EmployeeUsecase interface {
 	Create(ctx context.Context, input CreateEmployeeRequest) (employee *Employee, err error)
+	BulkCreate(ctx context.Context, input BulkCreateEmployeeRequest, bestEffort bool) (results []*BulkCreateEmployeeResult, err error)
 	FindByID(ctx context.Context, id int64) (employee *Employee, err error)
-	Update(ctx context.Context, employeeID int64, input UpdateEmployeeRequest) (employee *Employee, err error)
-	DeleteByID(ctx context.Context, employeeID int64) (err error)
+	Update(ctx context.Context, employeeID int64, input UpdateEmployeeRequest, expectedVersion int64) (employee *Employee, err error)
+	Patch(ctx context.Context, employeeID int64, input PatchEmployeeRequest, expectedVersion int64) (employee *Employee, err error)
+	DeleteByID(ctx context.Context, employeeID int64, expectedVersion int64) (err error)
 	SearchByCriteria(ctx context.Context, searchCriteria EmployeeSearchCriteria) (employees []*Employee, count int64, err error)
+	SearchByCursor(ctx context.Context, searchCriteria EmployeeSearchCriteria) (employees []*Employee, nextCursor string, count int64, err error)
+	SearchByQuery(ctx context.Context, searchCriteria EmployeeSearchCriteria) (results []*EmployeeSearchResult, count int64, err error)
 	GetDistinctPositions(ctx context.Context) ([]string, error)
+	Suggest(ctx context.Context, criteria EmployeeSuggestCriteria) ([]*EmployeeSuggestion, error)
+	FindHistoryByCriteria(ctx context.Context, criteria EmployeeAuditLogCriteria) (logs []*EmployeeAuditLog, count int64, err error)
+	Import(ctx context.Context, csvReader io.Reader, opts EmployeeImportOptions) (result *EmployeeImportResult, err error)
+	Export(ctx context.Context, criteria EmployeeSearchCriteria, fn func(employees []*Employee) error) error
+	FindReports(ctx context.Context, employeeID int64, depth int) (node *OrgChartNode, err error)
+	GetOrgChart(ctx context.Context, depth int) (nodes []*OrgChartNode, err error)
+	FindSalaryHistory(ctx context.Context, criteria SalaryChangeCriteria) (changes []*SalaryChange, count int64, err error)
+	CreateSalaryChange(ctx context.Context, employeeID int64, input CreateSalaryChangeRequest) (change *SalaryChange, err error)
+	ApplyDueSalaryChanges(ctx context.Context) (applied int, err error)
 }
 
 type EmployeeRepository interface {
 	Create(ctx context.Context, employee *Employee) error
+	CreateInBatch(ctx context.Context, employees []*Employee) error
 	FindByID(ctx context.Context, id int64) (*Employee, error)
+	FindByExternalKey(ctx context.Context, externalKey string) (*Employee, error)
+	FindAllByIDsWithDeleted(ctx context.Context, ids []int64) ([]*Employee, error)
 	Update(ctx context.Context, employee *Employee) (err error)
-	Delete(ctx context.Context, id int64) error
+	Delete(ctx context.Context, id int64, version int64) error
 	SearchByPage(ctx context.Context, searchCriteria EmployeeSearchCriteria) (ids []int64, count int64, err error)
-	GetDistinctPositions(ctx context.Context) ([]string, error)
+	SearchByCursor(ctx context.Context, searchCriteria EmployeeSearchCriteria, cursor *EmployeeCursor) (ids []int64, count int64, err error)
+	SearchByQuery(ctx context.Context, searchCriteria EmployeeSearchCriteria) (hits []*EmployeeSearchHit, count int64, err error)
+	FindAllAfterID(ctx context.Context, criteria EmployeeSearchCriteria, afterID int64, limit int) ([]*Employee, error)
+	FindReportingSalaries(ctx context.Context, criteria EmployeeSearchCriteria, ids []int64) (map[int64]Money, error)
+	FindSuggestions(ctx context.Context, criteria EmployeeSuggestCriteria) ([]*EmployeeSuggestion, error)
+	FindAuditLogsByCriteria(ctx context.Context, criteria EmployeeAuditLogCriteria) (logs []*EmployeeAuditLog, count int64, err error)
+	FindReportingTree(ctx context.Context, employeeID int64, depth int) (*OrgChartNode, error)
+	FindOrgChart(ctx context.Context, depth int) ([]*OrgChartNode, error)
+	FindManagerChain(ctx context.Context, employeeID int64) ([]int64, error)
+	FindSalaryChangesByCriteria(ctx context.Context, criteria SalaryChangeCriteria) (changes []*SalaryChange, count int64, err error)
+	CreateSalaryChange(ctx context.Context, change *SalaryChange) error
+	FindEmployeeIDsWithDueSalaryChanges(ctx context.Context, day time.Time) ([]int64, error)
+	ApplyDueSalaryChanges(ctx context.Context, employeeID int64, day time.Time) (applied bool, err error)
+	FindSalariesOn(ctx context.Context, employeeIDs []int64, day time.Time) (map[int64]*SalaryChange, error)
+	FindDistinctCurrencies(ctx context.Context) ([]string, error)
 }
 
 // Employee :nodoc:
 type Employee struct {
-	ID        int64          `json:"id" gorm:"<-:create; primary_key;AUTO_INCREMENT"`
-	Name      string         `json:"name"`
-	Position  string         `json:"position"`
-	Salary    float64        `json:"salary"`
-	CreatedAt *time.Time     `json:"created_at" gorm:"->;<-:create"`
-	UpdatedAt *time.Time     `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `json:"deleted_at"`
+	ID           int64          `json:"id" gorm:"<-:create; primary_key;AUTO_INCREMENT"`
+	ExternalKey  *string        `json:"external_key"`
+	Name         string         `json:"name"`
+	Position     string         `json:"position"`
+	Salary       Money          `json:"salary"`
+	Currency     string         `json:"currency"`
+	DepartmentID *int64         `json:"department_id"`
+	ManagerID    *int64         `json:"manager_id"`
+	Version      int64          `json:"version"`
+	CreatedAt    *time.Time     `json:"created_at" gorm:"->;<-:create"`
+	UpdatedAt    *time.Time     `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `json:"deleted_at"`
+	// ReportingSalary the salary normalized into ReportingCurrency by a search, it isn't stored
+	ReportingSalary   *Money `json:"reporting_salary,omitempty" gorm:"-"`
+	ReportingCurrency string `json:"reporting_currency,omitempty" gorm:"-"`
 }
 
 // CreateEmployeeRequest DTO for creating a new employee
 type CreateEmployeeRequest struct {
-	Name     string  `json:"name" validate:"required"`
-	Position string  `json:"position" validate:"required"`
-	Salary   float64 `json:"salary" validate:"required"`
+	Name     string `json:"name" validate:"required"`
+	Position string `json:"position" validate:"required"`
+	Salary   Money  `json:"salary" validate:"required,money"`
+	// Currency the ISO 4217 code of the salary, the BaseCurrency when empty
+	Currency string `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	// ExternalKey identifies the employee in an external system, e.g. the HR spreadsheet, it must be unique
+	ExternalKey string `json:"external_key,omitempty" validate:"omitempty,max=64"`
+	// DepartmentID the department the employee belongs to, none when nil
+	DepartmentID *int64 `json:"department_id,omitempty" validate:"omitempty,gt=0"`
+	// ManagerID the employee this one reports to, none when nil
+	ManagerID *int64 `json:"manager_id,omitempty" validate:"omitempty,gt=0"`
+	// OverrideSalaryBand accepts a salary out of the band of the position, it requires the override permission
+	OverrideSalaryBand bool `json:"override_salary_band,omitempty"`
 }
 
 func (c *CreateEmployeeRequest) Validate() error {
 	return validate.Struct(c)
 }
 
-// UpdateEmployeeRequest DTO for updating an employee
+// BulkCreateEmployeeRequest DTO for creating several employees at once
+type BulkCreateEmployeeRequest struct {
+	Items []CreateEmployeeRequest `json:"items"`
+}
+
+// BulkCreateStatus the outcome of an item of a bulk creation
+type BulkCreateStatus string
+
+const (
+	BulkCreateStatusCreated BulkCreateStatus = "created"
+	BulkCreateStatusInvalid BulkCreateStatus = "invalid"
+	BulkCreateStatusFailed  BulkCreateStatus = "failed"
+	// BulkCreateStatusSkipped a valid item which is not inserted because another item of the transaction is invalid
+	BulkCreateStatusSkipped BulkCreateStatus = "skipped"
+)
+
+// BulkCreateEmployeeResult the result of an item of a bulk creation, in the same order as the request items
+type BulkCreateEmployeeResult struct {
+	Index  int               `json:"index"`
+	Status BulkCreateStatus  `json:"status"`
+	ID     int64             `json:"id,omitempty"`
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// UpdateEmployeeRequest DTO for replacing an employee, every field is required except the department, the manager
+// and the currency, an employee without DepartmentID or ManagerID is removed from its department or manager
 type UpdateEmployeeRequest struct {
-	Name     string  `json:"name,omitempty"`
-	Position string  `json:"position,omitempty"`
-	Salary   float64 `json:"salary,omitempty"`
+	Name     string `json:"name" validate:"required"`
+	Position string `json:"position" validate:"required"`
+	Salary   Money  `json:"salary" validate:"required,money"`
+	// Currency the ISO 4217 code of the salary, the current currency of the employee is kept when empty
+	Currency     string `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	DepartmentID *int64 `json:"department_id,omitempty" validate:"omitempty,gt=0"`
+	ManagerID    *int64 `json:"manager_id,omitempty" validate:"omitempty,gt=0"`
+	// OverrideSalaryBand accepts a salary out of the band of the position, it requires the override permission
+	OverrideSalaryBand bool `json:"override_salary_band,omitempty"`
 }
 
 func (c *UpdateEmployeeRequest) Validate() error {
 	return validate.Struct(c)
 }
 
+// PatchEmployeeRequest DTO for partially updating an employee, it is a RFC 7396 JSON merge patch document
+// applied on top of the UpdateEmployeeRequest of the current employee
+type PatchEmployeeRequest []byte
+
 // EmployeeSearchCriteria :nodoc:
 type EmployeeSearchCriteria struct {
 	Name     string `json:"name"`
 	Position string `json:"position"`
-	Page     int64  `json:"page"`
-	Size     int64  `json:"size"`
-	SortBy   string `json:"sort_by"`
-	SortDir  string `json:"sort_dir"`
+	// DepartmentID only matches the employees of the department when set
+	DepartmentID int64  `json:"department_id"`
+	Page         int64  `json:"page"`
+	Size         int64  `json:"size"`
+	SortBy       string `json:"sort_by"`
+	SortDir      string `json:"sort_dir"`
+	// Query matches the names by similarity, tolerating typos, and the words of the name and position by prefix
+	Query string `json:"q"`
+	// Filter the conditions on top of the Name and Position shorthands
+	Filter EmployeeFilter `json:"filter"`
+	// Cursor is the next_cursor of the previous page of a cursor paginated search, empty for the first page
+	Cursor string `json:"cursor"`
+	// WithCount counts every matching employee on a cursor paginated search, the offset pagination always counts
+	WithCount bool `json:"with_count"`
+	// Sort is SortBy and SortDir once validated by ParseSort
+	Sort []EmployeeSortField `json:"-"`
+	// ReportingCurrency normalizes the salaries into this currency with the rates effective on RateDate,
+	// the salary filters and sort then apply to the normalized salaries
+	ReportingCurrency string `json:"reporting_currency"`
+	// RateDate the YYYY-MM-DD day of the rates of ReportingCurrency, today when empty
+	RateDate string `json:"rate_date"`
+	// Rates the rates of RateDate, loaded by the usecase when the salaries are normalized
+	Rates *ExchangeRates `json:"-"`
 }
 
-// SetDefaultValue will set default value for page and size if zero
+// IsReporting check whether the salaries are normalized into a reporting currency
+func (c *EmployeeSearchCriteria) IsReporting() bool {
+	return c.ReportingCurrency != "" || c.RateDate != ""
+}
+
+// Reporting return the reporting currency and the day of its rates, e.g. USD@2026-10-17,
+// empty when the salaries aren't normalized
+func (c *EmployeeSearchCriteria) Reporting() string {
+	if !c.IsReporting() {
+		return ""
+	}
+
+	return c.ReportingCurrency + "@" + c.RateDate
+}
+
+// ParseSort validate SortBy and SortDir against the sortable columns into Sort, see ParseEmployeeSort
+func (c *EmployeeSearchCriteria) ParseSort() (err error) {
+	c.Sort, err = ParseEmployeeSort(c.SortBy, c.SortDir)
+	return err
+}
+
+// SetDefaultValue will set default value for page and size if zero, the size is lowered to config.SearchMaxSize
 func (c *EmployeeSearchCriteria) SetDefaultValue() {
 	if c.Page == 0 {
 		c.Page = 1
 	}
-	if c.Size == 0 {
+	if c.Size <= 0 {
 		c.Size = 10
 	}
+	if maxSize := config.SearchMaxSize(); c.Size > maxSize {
+		c.Size = maxSize
+	}
 	if c.SortBy == "" {
 		c.SortBy = "created_at"
 	}
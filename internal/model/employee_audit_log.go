@@ -0,0 +1,44 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EmployeeAuditAction the kind of mutation recorded in the audit log
+type EmployeeAuditAction string
+
+const (
+	EmployeeAuditActionCreate EmployeeAuditAction = "create"
+	EmployeeAuditActionUpdate EmployeeAuditAction = "update"
+	EmployeeAuditActionDelete EmployeeAuditAction = "delete"
+)
+
+// EmployeeAuditLog an append-only record of a mutation on an employee.
+// Before and After only hold the fields changed by the mutation.
+type EmployeeAuditLog struct {
+	ID         int64               `json:"id" gorm:"<-:create; primary_key;AUTO_INCREMENT"`
+	EmployeeID int64               `json:"employee_id"`
+	Actor      string              `json:"actor"`
+	Action     EmployeeAuditAction `json:"action"`
+	Before     json.RawMessage     `json:"before" gorm:"type:jsonb"`
+	After      json.RawMessage     `json:"after" gorm:"type:jsonb"`
+	CreatedAt  *time.Time          `json:"created_at" gorm:"->;<-:create"`
+}
+
+// EmployeeAuditLogCriteria :nodoc:
+type EmployeeAuditLogCriteria struct {
+	EmployeeID int64 `json:"employee_id"`
+	Page       int64 `json:"page"`
+	Size       int64 `json:"size"`
+}
+
+// SetDefaultValue will set default value for page and size if zero
+func (c *EmployeeAuditLogCriteria) SetDefaultValue() {
+	if c.Page <= 0 {
+		c.Page = 1
+	}
+	if c.Size <= 0 {
+		c.Size = 10
+	}
+}
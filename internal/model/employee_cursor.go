@@ -0,0 +1,73 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// EmployeeCursor the sort key of the last employee of a page, a cursor paginated search resumes right after it.
+// Values holds the value of each sort field, the id tiebreaker included. Reporting is the reporting currency
+// of the search, see EmployeeSearchCriteria.Reporting, the salary value is then the normalized salary.
+// It is sent to the clients as an opaque string, see Encode.
+type EmployeeCursor struct {
+	Sort      []EmployeeSortField
+	Values    []any
+	Reporting string
+}
+
+type employeeCursorJSON struct {
+	Sort      string            `json:"s"`
+	Values    []json.RawMessage `json:"v"`
+	Reporting string            `json:"r,omitempty"`
+}
+
+// NewEmployeeCursor create the cursor pointing after the employee for the given sort and reporting currency
+func NewEmployeeCursor(employee *Employee, sort []EmployeeSortField, reporting string) *EmployeeCursor {
+	cursor := &EmployeeCursor{Sort: sort, Reporting: reporting}
+	for _, field := range sort {
+		cursor.Values = append(cursor.Values, employeeSortColumns[field.Column].value(employee))
+	}
+
+	return cursor
+}
+
+// Encode return the opaque representation of the cursor
+func (c *EmployeeCursor) Encode() string {
+	raw := employeeCursorJSON{Sort: FormatEmployeeSort(c.Sort), Reporting: c.Reporting}
+	for _, value := range c.Values {
+		data, _ := json.Marshal(value)
+		raw.Values = append(raw.Values, data)
+	}
+
+	data, _ := json.Marshal(raw)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeEmployeeCursor parse a cursor returned by Encode, each value is decoded into the type of its sort column
+func DecodeEmployeeCursor(s string) (*EmployeeCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	raw := employeeCursorJSON{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	sort, err := ParseEmployeeSort(raw.Sort, "")
+	if err != nil || len(sort) != len(raw.Values) {
+		return nil, ErrInvalidCursor
+	}
+
+	cursor := &EmployeeCursor{Sort: sort, Reporting: raw.Reporting}
+	for idx, field := range sort {
+		value := employeeSortColumns[field.Column].zero()
+		if err := json.Unmarshal(raw.Values[idx], value); err != nil {
+			return nil, ErrInvalidCursor
+		}
+		cursor.Values = append(cursor.Values, value)
+	}
+
+	return cursor, nil
+}
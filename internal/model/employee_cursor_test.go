@@ -0,0 +1,103 @@
+package model
+
+import (
+	"encoding/base64"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEmployeeCursor_EncodeDecode(t *testing.T) {
+	createdAt := time.Date(2026, time.October, 17, 9, 30, 0, 123456789, time.UTC)
+	reportingSalary := Money(64516)
+	employee := &Employee{
+		ID:              42,
+		Name:            "Siti Rahma",
+		Position:        "Sales Rep",
+		Salary:          1000000050,
+		CreatedAt:       &createdAt,
+		UpdatedAt:       &createdAt,
+		ReportingSalary: &reportingSalary,
+	}
+
+	tests := []struct {
+		name      string
+		sortBy    string
+		reporting string
+		want      []any
+	}{
+		{name: "the id", sortBy: "-id", want: []any{int64(42)}},
+		{name: "the strings", sortBy: "position,-name", want: []any{"Sales Rep", "Siti Rahma", int64(42)}},
+		{name: "the salary", sortBy: "-salary", want: []any{Money(1000000050), int64(42)}},
+		{name: "the reporting salary", sortBy: "salary", reporting: "USD", want: []any{reportingSalary, int64(42)}},
+		{name: "the timestamps", sortBy: "created_at,-updated_at", want: []any{createdAt, createdAt, int64(42)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sort, err := ParseEmployeeSort(tt.sortBy, "")
+			if err != nil {
+				t.Fatalf("ParseEmployeeSort(%q) error = %v", tt.sortBy, err)
+			}
+
+			e := *employee
+			if tt.reporting == "" {
+				e.ReportingSalary = nil
+			}
+
+			got, err := DecodeEmployeeCursor(NewEmployeeCursor(&e, sort, tt.reporting).Encode())
+			if err != nil {
+				t.Fatalf("DecodeEmployeeCursor() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got.Sort, sort) || got.Reporting != tt.reporting {
+				t.Errorf("DecodeEmployeeCursor() sort = %v, reporting = %q, want %v, %q", got.Sort, got.Reporting, sort, tt.reporting)
+			}
+
+			if len(got.Values) != len(tt.want) {
+				t.Fatalf("DecodeEmployeeCursor() values = %v, want %v", got.Values, tt.want)
+			}
+
+			for idx, value := range got.Values {
+				// the values are decoded into pointers to the type of their column
+				value = reflect.ValueOf(value).Elem().Interface()
+				if at, ok := value.(time.Time); ok {
+					if !at.Equal(tt.want[idx].(time.Time)) {
+						t.Errorf("value %d = %v, want %v", idx, at, tt.want[idx])
+					}
+					continue
+				}
+
+				if value != tt.want[idx] {
+					t.Errorf("value %d = %#v, want %#v", idx, value, tt.want[idx])
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeEmployeeCursor_Invalid(t *testing.T) {
+	encode := func(s string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(s))
+	}
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "not base64", cursor: "not a cursor!"},
+		{name: "not JSON", cursor: encode("{")},
+		{name: "a column out of the whitelist", cursor: encode(`{"s":"password,id","v":["x",1]}`)},
+		{name: "a value missing", cursor: encode(`{"s":"name,id","v":["x"]}`)},
+		{name: "a value too many", cursor: encode(`{"s":"id","v":[1,2]}`)},
+		{name: "a value of the wrong type", cursor: encode(`{"s":"name,id","v":["x","y"]}`)},
+		{name: "an invalid salary", cursor: encode(`{"s":"salary,id","v":["1.001",1]}`)},
+		{name: "an invalid timestamp", cursor: encode(`{"s":"created_at,id","v":["yesterday",1]}`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := DecodeEmployeeCursor(tt.cursor); err != ErrInvalidCursor {
+				t.Errorf("DecodeEmployeeCursor(%q) = %v, %v, want ErrInvalidCursor", tt.cursor, got, err)
+			}
+		})
+	}
+}
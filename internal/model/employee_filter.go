@@ -0,0 +1,63 @@
+package model
+
+import "time"
+
+// EmployeeFilter the conditions of an employee search, every set condition must match.
+// In a query string a condition is written field[operator]=value, e.g. salary[gte]=10000000,
+// and in a JSON body {"salary": {"gte": 10000000}}.
+type EmployeeFilter struct {
+	Name         *StringFilter           `json:"name,omitempty"`
+	Position     *StringFilter           `json:"position,omitempty"`
+	Salary       *RangeFilter[Money]     `json:"salary,omitempty"`
+	CreatedAt    *RangeFilter[time.Time] `json:"created_at,omitempty"`
+	UpdatedAt    *RangeFilter[time.Time] `json:"updated_at,omitempty"`
+	DepartmentID *IDFilter               `json:"department_id,omitempty"`
+	// Not matches the employees which don't match all of its conditions
+	Not *EmployeeFilter `json:"not,omitempty"`
+	// IncludeDeleted also matches the deleted employees, it is only read from the top level filter
+	IncludeDeleted bool `json:"include_deleted,omitempty"`
+}
+
+// StringFilter conditions on a text column, Like is a case-insensitive substring match
+type StringFilter struct {
+	Eq   *string  `json:"eq,omitempty"`
+	Ne   *string  `json:"ne,omitempty"`
+	Like *string  `json:"like,omitempty"`
+	In   []string `json:"in,omitempty" validate:"max=100"`
+	Nin  []string `json:"nin,omitempty" validate:"max=100"`
+}
+
+// IDFilter conditions on a nullable reference column, Null matches the rows without (true) or with (false) a reference
+type IDFilter struct {
+	Eq   *int64  `json:"eq,omitempty"`
+	Ne   *int64  `json:"ne,omitempty"`
+	In   []int64 `json:"in,omitempty" validate:"max=100"`
+	Nin  []int64 `json:"nin,omitempty" validate:"max=100"`
+	Null *bool   `json:"null,omitempty"`
+}
+
+// RangeFilter conditions on a money or time column, the bounds can be combined into a range
+type RangeFilter[T Money | time.Time] struct {
+	Eq  *T `json:"eq,omitempty"`
+	Ne  *T `json:"ne,omitempty"`
+	Gt  *T `json:"gt,omitempty"`
+	Gte *T `json:"gte,omitempty"`
+	Lt  *T `json:"lt,omitempty"`
+	Lte *T `json:"lte,omitempty"`
+}
+
+// Validate :nodoc:
+func (f *EmployeeFilter) Validate() error {
+	return validate.Struct(f)
+}
+
+// HasSalary check whether the filter, or its Not filter, has a condition on the salary
+func (f *EmployeeFilter) HasSalary() bool {
+	return f != nil && (f.Salary != nil || f.Not.HasSalary())
+}
+
+// IsEmpty check whether the filter has no condition
+func (f *EmployeeFilter) IsEmpty() bool {
+	return f == nil || (f.Name == nil && f.Position == nil && f.Salary == nil &&
+		f.CreatedAt == nil && f.UpdatedAt == nil && f.DepartmentID == nil && f.Not.IsEmpty() && !f.IncludeDeleted)
+}
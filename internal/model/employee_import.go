@@ -0,0 +1,89 @@
+package model
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EmployeeImportOptions :nodoc:
+type EmployeeImportOptions struct {
+	// DryRun only validates the rows, nothing is written
+	DryRun bool `json:"dry_run"`
+	// Upsert updates the employee having the same external_key instead of rejecting the row
+	Upsert bool `json:"upsert"`
+}
+
+// EmployeeImportResult the summary of an import, Errors holds every rejected row
+type EmployeeImportResult struct {
+	DryRun   bool                      `json:"dry_run"`
+	Total    int                       `json:"total"`
+	Created  int                       `json:"created"`
+	Updated  int                       `json:"updated"`
+	Rejected int                       `json:"rejected"`
+	Errors   []*EmployeeImportRowError `json:"errors"`
+}
+
+// EmployeeImportRowError the reasons a row is rejected, keyed by column name
+type EmployeeImportRowError struct {
+	Line        int               `json:"line"`
+	ExternalKey string            `json:"external_key,omitempty"`
+	Errors      map[string]string `json:"errors"`
+}
+
+// WriteErrorReport write the rejected rows as CSV with the line, external_key, column and error columns
+func (r *EmployeeImportResult) WriteErrorReport(w io.Writer) error {
+	csvWriter := csv.NewWriter(w)
+	if err := csvWriter.Write([]string{"line", "external_key", "column", "error"}); err != nil {
+		return err
+	}
+
+	for _, rowErr := range r.Errors {
+		columns := make([]string, 0, len(rowErr.Errors))
+		for column := range rowErr.Errors {
+			columns = append(columns, column)
+		}
+		sort.Strings(columns)
+
+		for _, column := range columns {
+			record := []string{strconv.Itoa(rowErr.Line), rowErr.ExternalKey, column, rowErr.Errors[column]}
+			if err := csvWriter.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// EmployeeImportColumns maps the accepted CSV header names, case insensitive, onto the CreateEmployeeRequest fields
+var EmployeeImportColumns = map[string]string{
+	"external_key": "external_key",
+	"nip":          "external_key",
+	"name":         "name",
+	"nama":         "name",
+	"position":     "position",
+	"jabatan":      "position",
+	"salary":       "salary",
+	"gaji":         "salary",
+	"currency":     "currency",
+	"mata_uang":    "currency",
+}
+
+// NormalizeImportColumn return the field of the CSV header name, empty when the column is not imported
+func NormalizeImportColumn(header string) string {
+	return EmployeeImportColumns[strings.ToLower(strings.TrimSpace(header))]
+}
+
+// AddRowError reject a row
+func (r *EmployeeImportResult) AddRowError(line int, externalKey string, errors map[string]string) {
+	r.Rejected++
+	r.Errors = append(r.Errors, &EmployeeImportRowError{
+		Line:        line,
+		ExternalKey: externalKey,
+		Errors:      errors,
+	})
+}
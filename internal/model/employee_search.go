@@ -0,0 +1,15 @@
+package model
+
+// EmployeeSearchHit an employee matching the search query q, with its relevance
+type EmployeeSearchHit struct {
+	ID    int64   `json:"id"`
+	Score float64 `json:"score"`
+}
+
+// EmployeeSearchResult an employee of a search ranked by relevance. Highlights holds the matching fields
+// with the matched words wrapped in <mark></mark>, the rest of the text is HTML escaped.
+type EmployeeSearchResult struct {
+	Employee   *Employee         `json:"employee"`
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights,omitempty"`
+}
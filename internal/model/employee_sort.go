@@ -0,0 +1,100 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// employeeSortColumns the columns a search can be sorted on, with the value of an employee
+// and an empty value of the column type to decode a cursor into. The salary of a search normalized
+// into a reporting currency is sorted on the normalized salary.
+var employeeSortColumns = map[string]struct {
+	value func(e *Employee) any
+	zero  func() any
+}{
+	"id":         {func(e *Employee) any { return e.ID }, func() any { return new(int64) }},
+	"name":       {func(e *Employee) any { return e.Name }, func() any { return new(string) }},
+	"position":   {func(e *Employee) any { return e.Position }, func() any { return new(string) }},
+	"salary":     {employeeSortSalary, func() any { return new(Money) }},
+	"created_at": {func(e *Employee) any { return e.CreatedAt }, func() any { return new(time.Time) }},
+	"updated_at": {func(e *Employee) any { return e.UpdatedAt }, func() any { return new(time.Time) }},
+}
+
+func employeeSortSalary(e *Employee) any {
+	if e.ReportingSalary != nil {
+		return *e.ReportingSalary
+	}
+
+	return e.Salary
+}
+
+// EmployeeSortField a key of the search order
+type EmployeeSortField struct {
+	Column string
+	Desc   bool
+}
+
+// ParseEmployeeSort parse a comma separated list of columns, each descending when prefixed with "-",
+// e.g. "position,-salary,name". A single column without prefix is ordered by sortDir, which is the
+// legacy sort_by/sort_dir form. The id is appended as a tiebreaker, so the order is always deterministic.
+// ErrInvalidSortField is returned for a column out of the whitelist or listed twice.
+func ParseEmployeeSort(sortBy, sortDir string) ([]EmployeeSortField, error) {
+	keys := strings.Split(sortBy, ",")
+
+	var fields []EmployeeSortField
+	seen := map[string]bool{}
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
+		field := EmployeeSortField{Column: strings.TrimLeft(key, "+-")}
+		switch {
+		case strings.HasPrefix(key, "-"):
+			field.Desc = true
+		case strings.HasPrefix(key, "+"):
+		case len(keys) == 1:
+			switch strings.ToLower(sortDir) {
+			case "", "asc":
+			case "desc":
+				field.Desc = true
+			default:
+				return nil, ErrInvalidSortField
+			}
+		}
+
+		if _, ok := employeeSortColumns[field.Column]; !ok || seen[field.Column] || len(key)-len(field.Column) > 1 {
+			return nil, ErrInvalidSortField
+		}
+		seen[field.Column] = true
+
+		fields = append(fields, field)
+	}
+
+	if !seen["id"] {
+		fields = append(fields, EmployeeSortField{Column: "id", Desc: fields[len(fields)-1].Desc})
+	}
+
+	return fields, nil
+}
+
+// HasEmployeeSortColumn check whether the sort has a key on the column
+func HasEmployeeSortColumn(fields []EmployeeSortField, column string) bool {
+	for _, field := range fields {
+		if field.Column == column {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FormatEmployeeSort return the canonical form of a parsed sort, e.g. "position,-salary,name,id"
+func FormatEmployeeSort(fields []EmployeeSortField) string {
+	keys := make([]string, len(fields))
+	for idx, field := range fields {
+		keys[idx] = field.Column
+		if field.Desc {
+			keys[idx] = "-" + field.Column
+		}
+	}
+
+	return strings.Join(keys, ",")
+}
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseEmployeeSort(t *testing.T) {
+	tests := []struct {
+		name    string
+		sortBy  string
+		sortDir string
+		want    []EmployeeSortField
+		wantErr bool
+	}{
+		{
+			name:   "a single column, ascending by default",
+			sortBy: "name",
+			want:   []EmployeeSortField{{Column: "name"}, {Column: "id"}},
+		},
+		{
+			name:    "a single column with the legacy direction",
+			sortBy:  "salary",
+			sortDir: "DESC",
+			want:    []EmployeeSortField{{Column: "salary", Desc: true}, {Column: "id", Desc: true}},
+		},
+		{
+			name:    "a prefix wins over the legacy direction",
+			sortBy:  "+salary",
+			sortDir: "desc",
+			want:    []EmployeeSortField{{Column: "salary"}, {Column: "id"}},
+		},
+		{
+			name:   "several columns, the tiebreaker follows the last one",
+			sortBy: "position, -salary,name",
+			want:   []EmployeeSortField{{Column: "position"}, {Column: "salary", Desc: true}, {Column: "name"}, {Column: "id"}},
+		},
+		{
+			name:   "the direction of an explicit id is kept",
+			sortBy: "-id,name",
+			want:   []EmployeeSortField{{Column: "id", Desc: true}, {Column: "name"}},
+		},
+		{
+			name:    "the legacy direction is ignored with several columns",
+			sortBy:  "name,created_at",
+			sortDir: "sideways",
+			want:    []EmployeeSortField{{Column: "name"}, {Column: "created_at"}, {Column: "id"}},
+		},
+		{name: "an invalid legacy direction", sortBy: "name", sortDir: "sideways", wantErr: true},
+		{name: "a column out of the whitelist", sortBy: "password", wantErr: true},
+		{name: "a column listed twice", sortBy: "name,-name", wantErr: true},
+		{name: "a double prefix", sortBy: "--name", wantErr: true},
+		{name: "an empty key", sortBy: "name,", wantErr: true},
+		{name: "an empty sort", sortBy: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEmployeeSort(tt.sortBy, tt.sortDir)
+			if tt.wantErr {
+				if err != ErrInvalidSortField {
+					t.Errorf("ParseEmployeeSort(%q, %q) = %v, %v, want ErrInvalidSortField", tt.sortBy, tt.sortDir, got, err)
+				}
+				return
+			}
+
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseEmployeeSort(%q, %q) = %v, %v, want %v", tt.sortBy, tt.sortDir, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatEmployeeSort(t *testing.T) {
+	for _, sortBy := range []string{"name,id", "position,-salary,name,id", "-id", "-created_at,updated_at,-id"} {
+		fields, err := ParseEmployeeSort(sortBy, "")
+		if err != nil {
+			t.Fatalf("ParseEmployeeSort(%q) error = %v", sortBy, err)
+		}
+
+		if got := FormatEmployeeSort(fields); got != sortBy {
+			t.Errorf("FormatEmployeeSort(ParseEmployeeSort(%q)) = %q", sortBy, got)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package model
+
+// EmployeeSuggestField the fields which can be suggested
+type EmployeeSuggestField string
+
+const (
+	EmployeeSuggestFieldName     EmployeeSuggestField = "name"
+	EmployeeSuggestFieldPosition EmployeeSuggestField = "position"
+)
+
+// EmployeeSuggestCriteria :nodoc:
+type EmployeeSuggestCriteria struct {
+	Field EmployeeSuggestField `json:"field" validate:"required,oneof=name position"`
+	// Prefix matches the start of the value or of any of its words, case-insensitively
+	Prefix string `json:"prefix" validate:"max=100"`
+	Limit  int    `json:"limit" validate:"min=1,max=50"`
+}
+
+// SetDefaultValue will set default value for limit if zero
+func (c *EmployeeSuggestCriteria) SetDefaultValue() {
+	if c.Limit == 0 {
+		c.Limit = 10
+	}
+}
+
+// Validate :nodoc:
+func (c *EmployeeSuggestCriteria) Validate() error {
+	return validate.Struct(c)
+}
+
+// EmployeeSuggestion a value of the field with the number of employees having it
+type EmployeeSuggestion struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
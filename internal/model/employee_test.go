@@ -0,0 +1,36 @@
+package model
+
+import (
+	"github.com/irvankadhafi/employee-api/internal/config"
+	"testing"
+)
+
+func TestEmployeeSearchCriteria_SetDefaultValue(t *testing.T) {
+	maxSize := config.SearchMaxSize()
+
+	tests := []struct {
+		name     string
+		size     int64
+		wantSize int64
+	}{
+		{name: "default size", size: 0, wantSize: 10},
+		{name: "negative size", size: -5, wantSize: 10},
+		{name: "size kept", size: 25, wantSize: 25},
+		{name: "maximum size kept", size: maxSize, wantSize: maxSize},
+		{name: "size clamped", size: maxSize + 1, wantSize: maxSize},
+		{name: "huge size clamped", size: 1 << 40, wantSize: maxSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			criteria := EmployeeSearchCriteria{Size: tt.size}
+			criteria.SetDefaultValue()
+
+			if criteria.Size != tt.wantSize {
+				t.Errorf("Size = %d, want %d", criteria.Size, tt.wantSize)
+			}
+			if criteria.Page != 1 {
+				t.Errorf("Page = %d, want 1", criteria.Page)
+			}
+		})
+	}
+}
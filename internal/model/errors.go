@@ -0,0 +1,34 @@
+package model
+
+import "errors"
+
+var (
+	// ErrVersionConflict returned by the repository when a conditional write finds another version of the row
+	ErrVersionConflict = errors.New("version conflict")
+	// ErrInvalidCursor returned when a pagination cursor can't be decoded
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrInvalidSortField returned when a search is sorted on a column out of the whitelist
+	ErrInvalidSortField = errors.New("invalid sort field")
+	// ErrDepartmentHasMembers returned when deleting a department which still has employees
+	ErrDepartmentHasMembers = errors.New("department has members")
+	// ErrDepartmentNotFound returned when an employee is assigned to a department which doesn't exist
+	ErrDepartmentNotFound = errors.New("department not found")
+	// ErrManagerNotFound returned when an employee reports to a manager which doesn't exist
+	ErrManagerNotFound = errors.New("manager not found")
+	// ErrManagerCycle returned when an employee would end up reporting to itself, directly or not
+	ErrManagerCycle = errors.New("manager cycle")
+	// ErrPositionInUse returned when deleting or renaming a position which employees still hold
+	ErrPositionInUse = errors.New("position in use")
+	// ErrPositionNotFound returned when an employee holds a position out of the catalog
+	ErrPositionNotFound = errors.New("position not found")
+	// ErrPayrollRunInProgress returned when creating a payroll run while another run of the period is in progress
+	ErrPayrollRunInProgress = errors.New("payroll run in progress")
+	// ErrPayrollRunApproved returned when creating a payroll run of a period whose run is already approved or paid
+	ErrPayrollRunApproved = errors.New("payroll run approved")
+	// ErrPayrollRunStatusChanged returned when a payroll run is no longer in the status it was moved from
+	ErrPayrollRunStatusChanged = errors.New("payroll run status changed")
+	// ErrPayrollRunReadOnly returned when deleting an approved payroll run
+	ErrPayrollRunReadOnly = errors.New("payroll run read-only")
+	// ErrNoExchangeRate returned when converting an amount of a currency which has no rate on the day
+	ErrNoExchangeRate = errors.New("no exchange rate")
+)
@@ -0,0 +1,170 @@
+package model
+
+import (
+	"context"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+// BaseCurrency the currency the exchange rates are quoted in, the payroll and the salary bands of the positions
+// are in it too
+const BaseCurrency = "IDR"
+
+type ExchangeRateUsecase interface {
+	Create(ctx context.Context, input CreateExchangeRateRequest) (rate *ExchangeRate, err error)
+	FindAllByCriteria(ctx context.Context, criteria ExchangeRateCriteria) (rates []*ExchangeRate, count int64, err error)
+	DeleteByID(ctx context.Context, id int64) (err error)
+	Import(ctx context.Context, csvReader io.Reader) (result *ExchangeRateImportResult, err error)
+}
+
+type ExchangeRateRepository interface {
+	Upsert(ctx context.Context, rates []*ExchangeRate) error
+	FindByID(ctx context.Context, id int64) (*ExchangeRate, error)
+	FindAllByCriteria(ctx context.Context, criteria ExchangeRateCriteria) (rates []*ExchangeRate, count int64, err error)
+	FindRatesOn(ctx context.Context, currencies []string, day time.Time) (*ExchangeRates, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+// ExchangeRate the rate of a currency from its effective date until the next rate of the currency
+type ExchangeRate struct {
+	ID       int64  `json:"id" gorm:"<-:create; primary_key;AUTO_INCREMENT"`
+	Currency string `json:"currency"`
+	// Rate the amount of BaseCurrency of one unit of the currency, e.g. 15750.5 for USD
+	Rate float64 `json:"rate"`
+	// EffectiveFrom the first day of the rate, at midnight UTC
+	EffectiveFrom time.Time  `json:"effective_from" gorm:"type:date"`
+	CreatedBy     string     `json:"created_by"`
+	CreatedAt     *time.Time `json:"created_at" gorm:"->;<-:create"`
+	UpdatedAt     *time.Time `json:"updated_at"`
+}
+
+// CreateExchangeRateRequest DTO for recording the rate of a currency, it replaces the rate of the currency
+// effective on the same day. It is effective today when EffectiveFrom is empty.
+type CreateExchangeRateRequest struct {
+	// Currency the ISO 4217 code, upper case, the BaseCurrency has no rate
+	Currency      string  `json:"currency" validate:"required,iso4217,ne=IDR"`
+	Rate          float64 `json:"rate" validate:"required,gt=0"`
+	EffectiveFrom string  `json:"effective_from,omitempty" validate:"omitempty,datetime=2006-01-02"`
+}
+
+func (c *CreateExchangeRateRequest) Validate() error {
+	return validate.Struct(c)
+}
+
+// ExchangeRateCriteria :nodoc:
+type ExchangeRateCriteria struct {
+	Currency string `json:"currency"`
+	Page     int64  `json:"page"`
+	Size     int64  `json:"size"`
+}
+
+// SetDefaultValue will set default value for page and size if zero
+func (c *ExchangeRateCriteria) SetDefaultValue() {
+	if c.Page <= 0 {
+		c.Page = 1
+	}
+	if c.Size <= 0 {
+		c.Size = 10
+	}
+}
+
+// ExchangeRateImportResult the summary of an exchange rates import, it is all or nothing: no rate is saved
+// when a row is rejected
+type ExchangeRateImportResult struct {
+	Total    int                           `json:"total"`
+	Imported int                           `json:"imported"`
+	Rejected int                           `json:"rejected"`
+	Errors   []*ExchangeRateImportRowError `json:"errors"`
+}
+
+// ExchangeRateImportRowError the reasons a row is rejected, keyed by column name
+type ExchangeRateImportRowError struct {
+	Line   int               `json:"line"`
+	Errors map[string]string `json:"errors"`
+}
+
+// AddRowError reject a row
+func (r *ExchangeRateImportResult) AddRowError(line int, errors map[string]string) {
+	r.Rejected++
+	r.Errors = append(r.Errors, &ExchangeRateImportRowError{
+		Line:   line,
+		Errors: errors,
+	})
+}
+
+// ExchangeRateImportColumns maps the accepted CSV header names, case insensitive, onto the CreateExchangeRateRequest fields
+var ExchangeRateImportColumns = map[string]string{
+	"currency":       "currency",
+	"mata_uang":      "currency",
+	"rate":           "rate",
+	"kurs":           "rate",
+	"effective_from": "effective_from",
+	"berlaku_mulai":  "effective_from",
+}
+
+// NormalizeExchangeRateImportColumn return the field of the CSV header name, empty when the column is not imported
+func NormalizeExchangeRateImportColumn(header string) string {
+	return ExchangeRateImportColumns[strings.ToLower(strings.TrimSpace(header))]
+}
+
+// ExchangeRates the rates effective on a day, keyed by currency. The BaseCurrency is always there with a rate of 1.
+type ExchangeRates struct {
+	Date  time.Time
+	Rates map[string]float64
+}
+
+// NewExchangeRates create the rates of the day, with only the BaseCurrency
+func NewExchangeRates(day time.Time) *ExchangeRates {
+	return &ExchangeRates{
+		Date:  day,
+		Rates: map[string]float64{BaseCurrency: 1},
+	}
+}
+
+// Rate return the amount of BaseCurrency of one unit of the currency, false when it has no rate on the day
+func (r *ExchangeRates) Rate(currency string) (float64, bool) {
+	rate, ok := r.Rates[currency]
+	return rate, ok
+}
+
+// Convert convert the amount from a currency into another through the BaseCurrency, rounded to the nearest
+// minor unit. ErrNoExchangeRate is returned when either currency has no rate on the day.
+func (r *ExchangeRates) Convert(amount Money, from, to string) (Money, error) {
+	if from == to {
+		return amount, nil
+	}
+
+	fromRate, ok := r.Rate(from)
+	if !ok {
+		return 0, ErrNoExchangeRate
+	}
+
+	toRate, ok := r.Rate(to)
+	if !ok {
+		return 0, ErrNoExchangeRate
+	}
+
+	return Money(math.Round(float64(amount) * fromRate / toRate)), nil
+}
+
+// IsCurrency check whether the code is an upper case ISO 4217 currency code
+func IsCurrency(code string) bool {
+	return validate.Var(code, "iso4217") == nil
+}
+
+// NormalizeCurrency return the upper case currency code, the BaseCurrency when it is empty
+func NormalizeCurrency(code string) string {
+	return NormalizeCurrencyOr(code, BaseCurrency)
+}
+
+// NormalizeCurrencyOr return the upper case currency code, the fallback when it is empty
+func NormalizeCurrencyOr(code, fallback string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return fallback
+	}
+
+	return code
+}
@@ -0,0 +1,211 @@
+package model
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"github.com/irvankadhafi/employee-api/utils"
+	"math"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// Money an amount in minor units, the sen of the Rupiah: Money(150) is Rp1,50.
+// It is stored as a BIGINT, so that adding or comparing amounts is exact.
+type Money int64
+
+// moneyScale the minor units of a major unit
+const moneyScale = 100
+
+// MaxMoney the largest amount, its major units still convert exactly to a float64
+const MaxMoney Money = 1<<53 - 1
+
+// ErrInvalidMoney returned when parsing an amount which isn't a decimal number with at most 2 decimals
+var ErrInvalidMoney = errors.New("invalid money amount")
+
+// MoneyJSONFormat how the amounts are written in JSON, they are read from both formats
+type MoneyJSONFormat string
+
+const (
+	// MoneyJSONString a decimal string of major units, e.g. "10000000.50", for the clients opting in
+	MoneyJSONString MoneyJSONFormat = "string"
+	// MoneyJSONNumber a number of major units, e.g. 10000000.50, the format of the clients written
+	// when the amounts were float64
+	MoneyJSONNumber MoneyJSONFormat = "number"
+)
+
+var moneyJSONString atomic.Bool
+
+// SetMoneyJSONFormat set how every amount is written in JSON, MoneyJSONNumber by default
+func SetMoneyJSONFormat(format MoneyJSONFormat) {
+	moneyJSONString.Store(format == MoneyJSONString)
+}
+
+// NewMoney convert an amount of major units to Money, rounded to the nearest minor unit
+func NewMoney(major float64) Money {
+	return Money(math.Round(major * moneyScale))
+}
+
+// ParseMoney parse a decimal amount of major units, e.g. 10000000 or -1250.5. A number in exponent notation,
+// as written by some JSON encoders, is accepted too and rounded to the nearest minor unit.
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if strings.ContainsAny(s, "eE") {
+		major, err := strconv.ParseFloat(s, 64)
+		if err != nil || math.Abs(major*moneyScale) > float64(MaxMoney) {
+			return 0, ErrInvalidMoney
+		}
+		return NewMoney(major), nil
+	}
+
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	integer, fraction, _ := strings.Cut(s, ".")
+	if (integer == "" && fraction == "") || len(fraction) > 2 || !isDigits(integer) || !isDigits(fraction) {
+		return 0, ErrInvalidMoney
+	}
+
+	fraction += strings.Repeat("0", 2-len(fraction))
+	amount, err := strconv.ParseInt("0"+integer+fraction, 10, 64)
+	if err != nil || Money(amount) > MaxMoney {
+		return 0, ErrInvalidMoney
+	}
+
+	if negative {
+		amount = -amount
+	}
+	return Money(amount), nil
+}
+
+// Mul multiply the amount by a rate, e.g. a percentage, rounded half away from zero to a multiple of unit
+func (m Money) Mul(rate float64, unit Money) Money {
+	return Money(math.Round(float64(m)*rate/float64(unit))) * unit
+}
+
+// Div divide the amount by n, rounded half away from zero to a multiple of unit
+func (m Money) Div(n int64, unit Money) Money {
+	divisor := n * int64(unit)
+	quotient, remainder := int64(m)/divisor, int64(m)%divisor
+	switch {
+	case remainder*2 >= divisor:
+		quotient++
+	case remainder*2 <= -divisor:
+		quotient--
+	}
+
+	return Money(quotient) * unit
+}
+
+// Round round the amount half away from zero to a multiple of unit, e.g. NewMoney(1) for the whole Rupiah
+func (m Money) Round(unit Money) Money {
+	return m.Div(1, unit)
+}
+
+// Float64 return the amount in major units
+func (m Money) Float64() float64 {
+	return float64(m) / moneyScale
+}
+
+// String return the amount as a decimal of major units with 2 decimals, e.g. 10000000.50
+func (m Money) String() string {
+	sign := ""
+	amount := int64(m)
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, amount/moneyScale, amount%moneyScale)
+}
+
+// Rupiah format the amount in Rupiah, the sen are only written when there are some, e.g. Rp10.000.000 or Rp1.250,50
+func (m Money) Rupiah() string {
+	sign := ""
+	amount := int64(m)
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+
+	rupiah := sign + utils.Int64ToRupiah(amount/moneyScale)
+	if sen := amount % moneyScale; sen != 0 {
+		rupiah += fmt.Sprintf(",%02d", sen)
+	}
+
+	return rupiah
+}
+
+// MarshalJSON write the amount in the format set by SetMoneyJSONFormat
+func (m Money) MarshalJSON() ([]byte, error) {
+	if moneyJSONString.Load() {
+		return []byte(`"` + m.String() + `"`), nil
+	}
+
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON read an amount of major units written as a decimal string or as a number
+func (m *Money) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+
+	amount, err := ParseMoney(string(bytes.Trim(b, `"`)))
+	if err != nil {
+		return err
+	}
+
+	*m = amount
+	return nil
+}
+
+// UnmarshalText read an amount of major units, it lets a YAML file hold amounts like 12000000
+func (m *Money) UnmarshalText(b []byte) error {
+	amount, err := ParseMoney(string(b))
+	if err != nil {
+		return err
+	}
+
+	*m = amount
+	return nil
+}
+
+// Value :nodoc:
+func (m Money) Value() (driver.Value, error) {
+	return int64(m), nil
+}
+
+// Scan :nodoc:
+func (m *Money) Scan(value any) error {
+	switch val := value.(type) {
+	case int64:
+		*m = Money(val)
+		return nil
+	case []byte:
+		return m.scanString(string(val))
+	case string:
+		return m.scanString(val)
+	default:
+		return fmt.Errorf("unsupported money value %T", value)
+	}
+}
+
+func (m *Money) scanString(s string) error {
+	amount, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	*m = Money(amount)
+	return nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,133 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Money
+		wantErr bool
+	}{
+		{in: "10000000", want: 1000000000},
+		{in: "10000000.5", want: 1000000050},
+		{in: "10000000.50", want: 1000000050},
+		{in: "-1250.05", want: -125005},
+		{in: " 0.01 ", want: 1},
+		{in: ".5", want: 50},
+		{in: "7.", want: 700},
+		{in: "1e3", want: 100000},
+		{in: "1.2345E2", want: 12345},
+		{in: "90071992547409.91", want: MaxMoney},
+		{in: "90071992547409.92", wantErr: true},
+		{in: "1.234", wantErr: true},
+		{in: "", wantErr: true},
+		{in: ".", wantErr: true},
+		{in: "-", wantErr: true},
+		{in: "1,000", wantErr: true},
+		{in: "+5", wantErr: true},
+		{in: "Rp5", wantErr: true},
+		{in: "1e400", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseMoney(tt.in)
+			if tt.wantErr {
+				if err != ErrInvalidMoney {
+					t.Errorf("ParseMoney(%q) = %d, %v, want ErrInvalidMoney", tt.in, got, err)
+				}
+				return
+			}
+
+			if err != nil || got != tt.want {
+				t.Errorf("ParseMoney(%q) = %d, %v, want %d", tt.in, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoney_MarshalJSON(t *testing.T) {
+	defer SetMoneyJSONFormat(MoneyJSONNumber)
+
+	// the clients of the float amounts keep reading numbers until the string format is set
+	if got, err := json.Marshal(Money(1000000050)); err != nil || string(got) != `10000000.50` {
+		t.Errorf("json.Marshal(1000000050) = %s, %v, want 10000000.50 by default", got, err)
+	}
+
+	tests := []struct {
+		name   string
+		format MoneyJSONFormat
+		amount Money
+		want   string
+	}{
+		{name: "string", format: MoneyJSONString, amount: 1000000050, want: `"10000000.50"`},
+		{name: "string of a negative amount", format: MoneyJSONString, amount: -5, want: `"-0.05"`},
+		{name: "string of zero", format: MoneyJSONString, amount: 0, want: `"0.00"`},
+		{name: "number", format: MoneyJSONNumber, amount: 1000000050, want: `10000000.50`},
+		{name: "number of a negative amount", format: MoneyJSONNumber, amount: -125005, want: `-1250.05`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetMoneyJSONFormat(tt.format)
+			got, err := json.Marshal(tt.amount)
+			if err != nil || string(got) != tt.want {
+				t.Errorf("json.Marshal(%d) = %s, %v, want %s", tt.amount, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoney_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Money
+		wantErr bool
+	}{
+		{in: `"10000000.50"`, want: 1000000050},
+		{in: `10000000.5`, want: 1000000050},
+		{in: `-1250`, want: -125000},
+		{in: `1.5e7`, want: 1500000000},
+		{in: `null`, want: 42},
+		{in: `"1.001"`, wantErr: true},
+		{in: `"abc"`, wantErr: true},
+		{in: `true`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			// null leaves the amount untouched
+			got := Money(42)
+			err := json.Unmarshal([]byte(tt.in), &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("json.Unmarshal(%s) = %d, want an error", tt.in, got)
+				}
+				return
+			}
+
+			if err != nil || got != tt.want {
+				t.Errorf("json.Unmarshal(%s) = %d, %v, want %d", tt.in, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoney_JSONRoundTrip(t *testing.T) {
+	defer SetMoneyJSONFormat(MoneyJSONNumber)
+
+	for _, format := range []MoneyJSONFormat{MoneyJSONString, MoneyJSONNumber} {
+		SetMoneyJSONFormat(format)
+		for _, amount := range []Money{0, 1, -1, 99, 1000000050, MaxMoney, -MaxMoney} {
+			b, err := json.Marshal(amount)
+			if err != nil {
+				t.Fatalf("json.Marshal(%d) error = %v", amount, err)
+			}
+
+			var got Money
+			if err := json.Unmarshal(b, &got); err != nil || got != amount {
+				t.Errorf("%s: json.Unmarshal(%s) = %d, %v, want %d", format, b, got, err, amount)
+			}
+		}
+	}
+}
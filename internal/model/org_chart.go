@@ -0,0 +1,14 @@
+package model
+
+// OrgChartNode an employee of the reporting tree along with the employees reporting to it.
+// The salary is deliberately left out, the tree is readable by every role.
+type OrgChartNode struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	Position     string `json:"position"`
+	DepartmentID *int64 `json:"department_id"`
+	ManagerID    *int64 `json:"manager_id"`
+	// DirectReports counts every direct report, Reports is empty beyond the requested depth
+	DirectReports int64           `json:"direct_reports"`
+	Reports       []*OrgChartNode `json:"reports" gorm:"-"`
+}
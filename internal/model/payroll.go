@@ -0,0 +1,283 @@
+package model
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+type PayrollUsecase interface {
+	GetPayslip(ctx context.Context, employeeID int64, period string) (payslip *Payslip, err error)
+	ExportPayslips(ctx context.Context, period string, fn func(payslips []*Payslip) error) error
+	CreateRun(ctx context.Context, period string) (run *PayrollRun, err error)
+	FindRunByID(ctx context.Context, id int64) (run *PayrollRun, err error)
+	FindRunsByCriteria(ctx context.Context, criteria PayrollRunCriteria) (runs []*PayrollRun, count int64, err error)
+	FindRunItemsByCriteria(ctx context.Context, criteria PayrollRunItemCriteria) (items []*PayrollRunItem, count int64, err error)
+	MoveRun(ctx context.Context, id int64, status PayrollRunStatus) (run *PayrollRun, err error)
+	DeleteRun(ctx context.Context, id int64) (err error)
+	FindProfile(ctx context.Context, employeeID int64) (profile *PayrollProfile, err error)
+	UpdateProfile(ctx context.Context, employeeID int64, input UpdatePayrollProfileRequest) (profile *PayrollProfile, err error)
+}
+
+type PayrollRepository interface {
+	FindProfileByEmployeeID(ctx context.Context, employeeID int64) (*PayrollProfile, error)
+	FindProfilesByEmployeeIDs(ctx context.Context, employeeIDs []int64) ([]*PayrollProfile, error)
+	UpsertProfile(ctx context.Context, profile *PayrollProfile) error
+	FindRunByPeriod(ctx context.Context, period string) (*PayrollRun, error)
+	CreateRun(ctx context.Context, run *PayrollRun, items []*PayrollRunItem) error
+	FindRunByID(ctx context.Context, id int64) (*PayrollRun, error)
+	FindRunsByCriteria(ctx context.Context, criteria PayrollRunCriteria) (runs []*PayrollRun, count int64, err error)
+	FindRunItemsByCriteria(ctx context.Context, criteria PayrollRunItemCriteria) (items []*PayrollRunItem, count int64, err error)
+	FindPaidOutItem(ctx context.Context, employeeID int64, period string) (*PayrollRunItem, error)
+	FindRunItemsByEmployeeIDs(ctx context.Context, runID int64, employeeIDs []int64) ([]*PayrollRunItem, error)
+	FindPaidOutRunByPeriod(ctx context.Context, period string) (*PayrollRun, error)
+	UpdateRunStatus(ctx context.Context, run *PayrollRun, from PayrollRunStatus) error
+	DeleteRun(ctx context.Context, id int64) error
+}
+
+// PayrollPeriodLayout the layout of a payroll period, a month
+const PayrollPeriodLayout = "2006-01"
+
+// PTKPStatus the marital status and number of dependents of an employee, it sets its PTKP
+type PTKPStatus string
+
+const (
+	PTKPStatusTK0 PTKPStatus = "TK/0"
+	PTKPStatusTK1 PTKPStatus = "TK/1"
+	PTKPStatusTK2 PTKPStatus = "TK/2"
+	PTKPStatusTK3 PTKPStatus = "TK/3"
+	PTKPStatusK0  PTKPStatus = "K/0"
+	PTKPStatusK1  PTKPStatus = "K/1"
+	PTKPStatusK2  PTKPStatus = "K/2"
+	PTKPStatusK3  PTKPStatus = "K/3"
+)
+
+// DefaultPTKPStatus the status of an employee without a payroll profile
+const DefaultPTKPStatus = PTKPStatusTK0
+
+// TERCategory the category of the TER (tarif efektif rata-rata) monthly PPh 21 rates, set by the PTKP status
+type TERCategory string
+
+const (
+	TERCategoryA TERCategory = "A"
+	TERCategoryB TERCategory = "B"
+	TERCategoryC TERCategory = "C"
+)
+
+// PayrollComponent a monthly allowance or deduction of an employee
+type PayrollComponent struct {
+	Name   string `json:"name" validate:"required,max=100"`
+	Amount Money  `json:"amount" validate:"gt=0,money"`
+	// Taxable tells whether an allowance is part of the PPh 21 gross income, a deduction is taken after tax
+	Taxable bool `json:"taxable,omitempty"`
+}
+
+// PayrollComponents is stored as a jsonb array
+type PayrollComponents []PayrollComponent
+
+// Value :nodoc:
+func (p PayrollComponents) Value() (driver.Value, error) {
+	if p == nil {
+		return "[]", nil
+	}
+
+	b, err := json.Marshal(p)
+	return string(b), err
+}
+
+// Scan :nodoc:
+func (p *PayrollComponents) Scan(value any) error {
+	switch val := value.(type) {
+	case nil:
+		*p = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(val, p)
+	case string:
+		return json.Unmarshal([]byte(val), p)
+	default:
+		return errors.New("invalid payroll components")
+	}
+}
+
+// PayrollProfile the payroll settings of an employee, an employee without one is TK/0 without any component
+type PayrollProfile struct {
+	EmployeeID int64             `json:"employee_id" gorm:"primary_key"`
+	PTKPStatus PTKPStatus        `json:"ptkp_status"`
+	Allowances PayrollComponents `json:"allowances" gorm:"type:jsonb"`
+	Deductions PayrollComponents `json:"deductions" gorm:"type:jsonb"`
+	CreatedAt  *time.Time        `json:"created_at" gorm:"->;<-:create"`
+	UpdatedAt  *time.Time        `json:"updated_at"`
+}
+
+// NewDefaultPayrollProfile return the profile of an employee which has none
+func NewDefaultPayrollProfile(employeeID int64) *PayrollProfile {
+	return &PayrollProfile{EmployeeID: employeeID, PTKPStatus: DefaultPTKPStatus}
+}
+
+// UpdatePayrollProfileRequest DTO for replacing the payroll profile of an employee
+type UpdatePayrollProfileRequest struct {
+	PTKPStatus PTKPStatus         `json:"ptkp_status" validate:"required,oneof=TK/0 TK/1 TK/2 TK/3 K/0 K/1 K/2 K/3"`
+	Allowances []PayrollComponent `json:"allowances" validate:"max=20,dive"`
+	Deductions []PayrollComponent `json:"deductions" validate:"max=20,dive"`
+}
+
+func (c *UpdatePayrollProfileRequest) Validate() error {
+	return validate.Struct(c)
+}
+
+// PayslipLine an amount of a payslip
+type PayslipLine struct {
+	Code   string `json:"code"`
+	Name   string `json:"name"`
+	Amount Money  `json:"amount"`
+}
+
+// Payslip the monthly gross-to-net pay of an employee, every amount is in whole Rupiah
+type Payslip struct {
+	EmployeeID       int64      `json:"employee_id"`
+	EmployeeName     string     `json:"employee_name"`
+	Position         string     `json:"position"`
+	Period           string     `json:"period"`
+	RateTableVersion string     `json:"rate_table_version"`
+	PTKPStatus       PTKPStatus `json:"ptkp_status"`
+	BaseSalary       Money      `json:"base_salary"`
+	// Salary the salary of the period in SalaryCurrency when it isn't paid in the BaseCurrency,
+	// the BaseSalary is converted from it at ExchangeRate, the rate effective on the last day of the period
+	Salary         *Money  `json:"salary,omitempty"`
+	SalaryCurrency string  `json:"salary_currency,omitempty"`
+	ExchangeRate   float64 `json:"exchange_rate,omitempty"`
+	// Allowances the allowances of the profile
+	Allowances []*PayslipLine `json:"allowances"`
+	// GrossPay the base salary and the allowances
+	GrossPay Money `json:"gross_pay"`
+	// Deductions the BPJS contributions of the employee, the PPh 21 and the deductions of the profile
+	Deductions      []*PayslipLine `json:"deductions"`
+	TotalDeductions Money          `json:"total_deductions"`
+	// EmployerContributions the BPJS contributions paid by the company on top of the gross pay
+	EmployerContributions      []*PayslipLine `json:"employer_contributions"`
+	TotalEmployerContributions Money          `json:"total_employer_contributions"`
+	// TaxableIncome the monthly gross income of the PPh 21, including the taxable benefits paid by the company
+	TaxableIncome Money `json:"taxable_income"`
+	// TERCategory and TERRate the TER rate the PPh 21 of January to November is withheld at, both are empty in
+	// December where the PPh 21 is the tax of the year minus the PPh 21 withheld since January, negative when it
+	// was overpaid
+	TERCategory TERCategory `json:"ter_category,omitempty"`
+	TERRate     float64     `json:"ter_rate,omitempty"`
+	PPh21       Money       `json:"pph21"`
+	// NetPay the take-home pay, the gross pay minus the deductions
+	NetPay Money `json:"net_pay"`
+}
+
+// PayrollRunStatus the state of a payroll run, it moves from draft to reviewed, approved then paid
+type PayrollRunStatus string
+
+const (
+	PayrollRunStatusDraft    PayrollRunStatus = "draft"
+	PayrollRunStatusReviewed PayrollRunStatus = "reviewed"
+	PayrollRunStatusApproved PayrollRunStatus = "approved"
+	PayrollRunStatusPaid     PayrollRunStatus = "paid"
+)
+
+// payrollRunTransitions the status a run moves to from each status
+var payrollRunTransitions = map[PayrollRunStatus]PayrollRunStatus{
+	PayrollRunStatusDraft:    PayrollRunStatusReviewed,
+	PayrollRunStatusReviewed: PayrollRunStatusApproved,
+	PayrollRunStatusApproved: PayrollRunStatusPaid,
+}
+
+// CanMoveTo check whether a run can move from the status to the next one
+func (s PayrollRunStatus) CanMoveTo(next PayrollRunStatus) bool {
+	return payrollRunTransitions[s] == next
+}
+
+// IsInProgress check whether the run isn't approved yet, only one run of a period may be in progress
+func (s PayrollRunStatus) IsInProgress() bool {
+	return s == PayrollRunStatusDraft || s == PayrollRunStatusReviewed
+}
+
+// PayrollRun the payroll of a period, the payslips of every active employee are snapshot in its items
+// when it is created. Once approved it is read-only, only its payment can be recorded.
+type PayrollRun struct {
+	ID                         int64            `json:"id" gorm:"<-:create; primary_key;AUTO_INCREMENT"`
+	Period                     string           `json:"period"`
+	Status                     PayrollRunStatus `json:"status"`
+	RateTableVersion           string           `json:"rate_table_version"`
+	Employees                  int              `json:"employees"`
+	TotalGrossPay              Money            `json:"total_gross_pay"`
+	TotalDeductions            Money            `json:"total_deductions"`
+	TotalPPh21                 Money            `json:"total_pph21"`
+	TotalNetPay                Money            `json:"total_net_pay"`
+	TotalEmployerContributions Money            `json:"total_employer_contributions"`
+	CreatedBy                  string           `json:"created_by"`
+	ReviewedBy                 *string          `json:"reviewed_by"`
+	ReviewedAt                 *time.Time       `json:"reviewed_at"`
+	ApprovedBy                 *string          `json:"approved_by"`
+	ApprovedAt                 *time.Time       `json:"approved_at"`
+	PaidBy                     *string          `json:"paid_by"`
+	PaidAt                     *time.Time       `json:"paid_at"`
+	CreatedAt                  *time.Time       `json:"created_at" gorm:"->;<-:create"`
+	UpdatedAt                  *time.Time       `json:"updated_at"`
+}
+
+// Add count the payslip in the totals of the run
+func (r *PayrollRun) Add(payslip *Payslip) {
+	r.Employees++
+	r.TotalGrossPay += payslip.GrossPay
+	r.TotalDeductions += payslip.TotalDeductions
+	r.TotalPPh21 += payslip.PPh21
+	r.TotalNetPay += payslip.NetPay
+	r.TotalEmployerContributions += payslip.TotalEmployerContributions
+}
+
+// PayrollRunItem the payslip of an employee snapshot by a payroll run, it is never updated
+type PayrollRunItem struct {
+	ID           int64      `json:"id" gorm:"<-:create; primary_key;AUTO_INCREMENT"`
+	PayrollRunID int64      `json:"payroll_run_id"`
+	EmployeeID   int64      `json:"employee_id"`
+	NetPay       Money      `json:"net_pay"`
+	Payslip      *Payslip   `json:"payslip" gorm:"type:jsonb;serializer:json"`
+	CreatedAt    *time.Time `json:"created_at" gorm:"->;<-:create"`
+}
+
+// PayrollRunCriteria :nodoc:
+type PayrollRunCriteria struct {
+	Period string           `json:"period"`
+	Status PayrollRunStatus `json:"status"`
+	Page   int64            `json:"page"`
+	Size   int64            `json:"size"`
+}
+
+// SetDefaultValue will set default value for page and size if zero
+func (c *PayrollRunCriteria) SetDefaultValue() {
+	if c.Page <= 0 {
+		c.Page = 1
+	}
+	if c.Size <= 0 {
+		c.Size = 10
+	}
+}
+
+// PayrollRunItemCriteria :nodoc:
+type PayrollRunItemCriteria struct {
+	PayrollRunID int64 `json:"payroll_run_id"`
+	Page         int64 `json:"page"`
+	Size         int64 `json:"size"`
+}
+
+// SetDefaultValue will set default value for page and size if zero
+func (c *PayrollRunItemCriteria) SetDefaultValue() {
+	if c.Page <= 0 {
+		c.Page = 1
+	}
+	if c.Size <= 0 {
+		c.Size = 10
+	}
+}
+
+// ParsePayrollPeriod parse a YYYY-MM period into its first day
+func ParsePayrollPeriod(period string) (time.Time, error) {
+	return time.Parse(PayrollPeriodLayout, period)
+}
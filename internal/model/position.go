@@ -0,0 +1,90 @@
+package model
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"time"
+)
+
+type PositionUsecase interface {
+	Create(ctx context.Context, input CreatePositionRequest) (position *Position, err error)
+	FindByID(ctx context.Context, id int64) (position *Position, err error)
+	FindAllByCriteria(ctx context.Context, criteria PositionCriteria) (positions []*Position, count int64, err error)
+	Update(ctx context.Context, id int64, input UpdatePositionRequest) (position *Position, err error)
+	DeleteByID(ctx context.Context, id int64) (err error)
+}
+
+type PositionRepository interface {
+	Create(ctx context.Context, position *Position) error
+	FindByID(ctx context.Context, id int64) (*Position, error)
+	FindByCode(ctx context.Context, code string) (*Position, error)
+	FindByTitle(ctx context.Context, title string) (*Position, error)
+	FindAllByCriteria(ctx context.Context, criteria PositionCriteria) (positions []*Position, count int64, err error)
+	FindAllTitles(ctx context.Context) ([]string, error)
+	Update(ctx context.Context, position *Position) error
+	Delete(ctx context.Context, id int64) error
+}
+
+// Position an entry of the positions catalog, the position of an employee must be the title of one of them
+// and, unless overridden, its salary must be within the band
+type Position struct {
+	ID        int64          `json:"id" gorm:"<-:create; primary_key;AUTO_INCREMENT"`
+	Code      string         `json:"code"`
+	Title     string         `json:"title"`
+	Level     int            `json:"level"`
+	MinSalary Money          `json:"min_salary"`
+	MaxSalary Money          `json:"max_salary"`
+	CreatedAt *time.Time     `json:"created_at" gorm:"->;<-:create"`
+	UpdatedAt *time.Time     `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at"`
+}
+
+// InBand check whether the salary is within the band of the position, both bounds included
+func (p *Position) InBand(salary Money) bool {
+	return salary >= p.MinSalary && salary <= p.MaxSalary
+}
+
+// CreatePositionRequest DTO for creating a new position, the code is stored upper case
+type CreatePositionRequest struct {
+	Code      string `json:"code" validate:"required,max=32"`
+	Title     string `json:"title" validate:"required,max=100"`
+	Level     int    `json:"level" validate:"required,gte=1"`
+	MinSalary Money  `json:"min_salary" validate:"gte=0,money"`
+	MaxSalary Money  `json:"max_salary" validate:"gtefield=MinSalary,money"`
+}
+
+func (c *CreatePositionRequest) Validate() error {
+	return validate.Struct(c)
+}
+
+// UpdatePositionRequest DTO for replacing a position, every field is overwritten
+type UpdatePositionRequest struct {
+	Code      string `json:"code" validate:"required,max=32"`
+	Title     string `json:"title" validate:"required,max=100"`
+	Level     int    `json:"level" validate:"required,gte=1"`
+	MinSalary Money  `json:"min_salary" validate:"gte=0,money"`
+	MaxSalary Money  `json:"max_salary" validate:"gtefield=MinSalary,money"`
+}
+
+func (c *UpdatePositionRequest) Validate() error {
+	return validate.Struct(c)
+}
+
+// PositionCriteria :nodoc:
+type PositionCriteria struct {
+	// Query matches the code or the title
+	Query string `json:"q"`
+	Level int    `json:"level"`
+	Page  int64  `json:"page"`
+	Size  int64  `json:"size"`
+}
+
+// SetDefaultValue will set default value for page and size if zero
+func (c *PositionCriteria) SetDefaultValue() {
+	if c.Page <= 0 {
+		c.Page = 1
+	}
+	if c.Size <= 0 {
+		c.Size = 10
+	}
+}
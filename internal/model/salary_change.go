@@ -0,0 +1,71 @@
+package model
+
+import "time"
+
+// SalaryChangeDateLayout the layout of the effective date of a salary change
+const SalaryChangeDateLayout = "2006-01-02"
+
+// SalaryChange an entry of the salary history of an employee. The salary of the employee is the amount and the
+// currency of its latest entry effective today, a future entry is applied once its effective date is reached.
+type SalaryChange struct {
+	ID         int64  `json:"id" gorm:"<-:create; primary_key;AUTO_INCREMENT"`
+	EmployeeID int64  `json:"employee_id"`
+	Amount     Money  `json:"amount"`
+	Currency   string `json:"currency"`
+	// EffectiveFrom the day the amount becomes the salary, at midnight UTC
+	EffectiveFrom time.Time `json:"effective_from" gorm:"type:date"`
+	Reason        string    `json:"reason"`
+	// ApprovedBy the user who recorded the change, or system
+	ApprovedBy string `json:"approved_by"`
+	// AppliedAt when the amount became the salary of the employee, nil while the change is ahead
+	AppliedAt *time.Time `json:"applied_at"`
+	CreatedAt *time.Time `json:"created_at" gorm:"->;<-:create"`
+}
+
+// IsEffective check whether the change is effective on the day
+func (s *SalaryChange) IsEffective(day time.Time) bool {
+	return !s.EffectiveFrom.After(day)
+}
+
+// CreateSalaryChangeRequest DTO for recording a salary change, it is effective today when EffectiveFrom is empty
+type CreateSalaryChangeRequest struct {
+	Amount Money `json:"amount" validate:"required,gt=0,money"`
+	// Currency the ISO 4217 code of the amount, the current currency of the employee when empty
+	Currency      string `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	EffectiveFrom string `json:"effective_from,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	Reason        string `json:"reason" validate:"required,max=255"`
+	// OverrideSalaryBand accepts an amount out of the band of the position, it requires the override permission
+	OverrideSalaryBand bool `json:"override_salary_band,omitempty"`
+}
+
+func (c *CreateSalaryChangeRequest) Validate() error {
+	return validate.Struct(c)
+}
+
+// SalaryChangeCriteria :nodoc:
+type SalaryChangeCriteria struct {
+	EmployeeID int64 `json:"employee_id"`
+	Page       int64 `json:"page"`
+	Size       int64 `json:"size"`
+}
+
+// SetDefaultValue will set default value for page and size if zero
+func (c *SalaryChangeCriteria) SetDefaultValue() {
+	if c.Page <= 0 {
+		c.Page = 1
+	}
+	if c.Size <= 0 {
+		c.Size = 10
+	}
+}
+
+// Today return the current day at midnight UTC, the way the effective dates are stored
+func Today() time.Time {
+	return ToDate(time.Now())
+}
+
+// ToDate return the day of t, in its own location, at midnight UTC
+func ToDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
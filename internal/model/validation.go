@@ -14,5 +14,10 @@ var initOnce sync.Once
 func init() {
 	initOnce.Do(func() {
 		validate = validator.New()
+		// money checks a Money is within MaxMoney, the other tags of a Money compare its minor units
+		_ = validate.RegisterValidation("money", func(fl validator.FieldLevel) bool {
+			amount := Money(fl.Field().Int())
+			return amount <= MaxMoney && amount >= -MaxMoney
+		})
 	})
 }
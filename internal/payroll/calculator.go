@@ -0,0 +1,183 @@
+package payroll
+
+import (
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"time"
+)
+
+// the codes of the payslip lines
+const (
+	CodeAllowance     = "allowance"
+	CodeDeduction     = "deduction"
+	CodeBPJSKesehatan = "bpjs_kesehatan"
+	CodeBPJSJHT       = "bpjs_jht"
+	CodeBPJSJP        = "bpjs_jp"
+	CodeBPJSJKK       = "bpjs_jkk"
+	CodeBPJSJKM       = "bpjs_jkm"
+	CodePPh21         = "pph21"
+)
+
+// rupiah the unit every amount of a payslip is rounded to
+var rupiah = model.NewMoney(1)
+
+// pkpRounding the PKP is rounded down to the thousand Rupiah
+var pkpRounding = model.NewMoney(1000)
+
+// YearToDate the sums of the payslips of the previous months of the year, the PPh 21 of December reconciles
+// the tax of the year with them
+type YearToDate struct {
+	// TaxableIncome the PPh 21 gross income
+	TaxableIncome model.Money
+	// PensionContributions the JHT and JP of the employee
+	PensionContributions model.Money
+	PPh21                model.Money
+}
+
+// Add sum the payslip of a previous month of the year
+func (y *YearToDate) Add(payslip *model.Payslip) {
+	y.TaxableIncome += payslip.TaxableIncome
+	y.PPh21 += payslip.PPh21
+	for _, line := range payslip.Deductions {
+		if line.Code == CodeBPJSJHT || line.Code == CodeBPJSJP {
+			y.PensionContributions += line.Amount
+		}
+	}
+}
+
+// Calculate compute the gross-to-net pay of an employee for a month with the rates of the table. Every amount is
+// computed in minor units and each line is rounded half away from zero to the whole Rupiah.
+//
+// The BPJS contributions apply to the wage, the base salary and every allowance, capped for the programs having
+// a salary cap. The PPh 21 gross income is the base salary, the taxable allowances and the BPJS Kesehatan, JKK and
+// JKM paid by the company. From January to November, the PPh 21 is this gross income at the TER rate of its
+// bracket in the category of the PTKP status (PP 58/2023). In December, it is the yearly tax of the gross income
+// of the year to date and of the month, minus the biaya jabatan, the JHT and JP of the employee and the PTKP,
+// minus the PPh 21 already withheld in the year to date.
+func Calculate(table *RateTable, month time.Month, baseSalary model.Money, profile *model.PayrollProfile, yearToDate YearToDate) *model.Payslip {
+	payslip := &model.Payslip{
+		RateTableVersion: table.Version,
+		PTKPStatus:       profile.PTKPStatus,
+		BaseSalary:       baseSalary.Round(rupiah),
+		Allowances:       []*model.PayslipLine{},
+	}
+
+	wage := payslip.BaseSalary
+	taxableIncome := payslip.BaseSalary
+	for _, allowance := range profile.Allowances {
+		line := &model.PayslipLine{Code: CodeAllowance, Name: allowance.Name, Amount: allowance.Amount.Round(rupiah)}
+		payslip.Allowances = append(payslip.Allowances, line)
+		wage += line.Amount
+		if allowance.Taxable {
+			taxableIncome += line.Amount
+		}
+	}
+	payslip.GrossPay = wage
+
+	kesehatan := newContribution(CodeBPJSKesehatan, "BPJS Kesehatan", table.BPJSKesehatan, wage)
+	jht := newContribution(CodeBPJSJHT, "BPJS Ketenagakerjaan JHT", table.BPJSJHT, wage)
+	jp := newContribution(CodeBPJSJP, "BPJS Ketenagakerjaan JP", table.BPJSJP, wage)
+	jkk := newContribution(CodeBPJSJKK, "BPJS Ketenagakerjaan JKK", table.BPJSJKK, wage)
+	jkm := newContribution(CodeBPJSJKM, "BPJS Ketenagakerjaan JKM", table.BPJSJKM, wage)
+
+	for _, c := range []contribution{kesehatan, jht, jp, jkk, jkm} {
+		if c.employer > 0 {
+			payslip.EmployerContributions = append(payslip.EmployerContributions, &model.PayslipLine{Code: c.code, Name: c.name, Amount: c.employer})
+			payslip.TotalEmployerContributions += c.employer
+		}
+	}
+
+	payslip.TaxableIncome = taxableIncome + kesehatan.employer + jkk.employer + jkm.employer
+	if month == time.December {
+		grossIncome := yearToDate.TaxableIncome + payslip.TaxableIncome
+		netIncome := grossIncome - positionCost(table.PositionCost, grossIncome) - yearToDate.PensionContributions - jht.employee - jp.employee
+		yearlyPPh21 := yearlyTax(table.TaxBrackets, taxableYearlyIncome(netIncome, table.PTKP[profile.PTKPStatus])).Round(rupiah)
+		payslip.PPh21 = yearlyPPh21 - yearToDate.PPh21
+	} else {
+		payslip.TERCategory = table.TERCategories[profile.PTKPStatus]
+		payslip.TERRate = terRate(table.TER[payslip.TERCategory], payslip.TaxableIncome)
+		payslip.PPh21 = payslip.TaxableIncome.Mul(payslip.TERRate, rupiah)
+	}
+
+	for _, c := range []contribution{kesehatan, jht, jp} {
+		if c.employee > 0 {
+			payslip.Deductions = append(payslip.Deductions, &model.PayslipLine{Code: c.code, Name: c.name, Amount: c.employee})
+		}
+	}
+	payslip.Deductions = append(payslip.Deductions, &model.PayslipLine{Code: CodePPh21, Name: "PPh 21", Amount: payslip.PPh21})
+	for _, deduction := range profile.Deductions {
+		payslip.Deductions = append(payslip.Deductions, &model.PayslipLine{Code: CodeDeduction, Name: deduction.Name, Amount: deduction.Amount.Round(rupiah)})
+	}
+
+	for _, line := range payslip.Deductions {
+		payslip.TotalDeductions += line.Amount
+	}
+	payslip.NetPay = payslip.GrossPay - payslip.TotalDeductions
+
+	return payslip
+}
+
+// contribution the monthly amounts of a BPJS program
+type contribution struct {
+	code, name         string
+	employee, employer model.Money
+}
+
+func newContribution(code, name string, rate ContributionRate, wage model.Money) contribution {
+	base := rate.base(wage)
+	return contribution{
+		code:     code,
+		name:     name,
+		employee: base.Mul(rate.EmployeeRate, rupiah),
+		employer: base.Mul(rate.EmployerRate, rupiah),
+	}
+}
+
+// positionCost return the biaya jabatan of the yearly gross income
+func positionCost(cost PositionCost, grossIncome model.Money) model.Money {
+	amount := grossIncome.Mul(cost.Rate, rupiah)
+	if yearlyCap := cost.MonthlyCap * 12; yearlyCap > 0 && amount > yearlyCap {
+		return yearlyCap
+	}
+
+	return amount
+}
+
+// taxableYearlyIncome return the PKP of the yearly net income
+func taxableYearlyIncome(netIncome, ptkp model.Money) model.Money {
+	pkp := netIncome - ptkp
+	if pkp <= 0 {
+		return 0
+	}
+
+	return pkp - pkp%pkpRounding
+}
+
+// terRate return the rate of the TER bracket of the monthly gross income
+func terRate(brackets []TaxBracket, grossIncome model.Money) float64 {
+	for _, bracket := range brackets {
+		if bracket.UpTo == 0 || grossIncome <= bracket.UpTo {
+			return bracket.Rate
+		}
+	}
+
+	return 0
+}
+
+// yearlyTax apply the progressive brackets to the PKP, the tax of each bracket is rounded to the minor unit
+func yearlyTax(brackets []TaxBracket, pkp model.Money) model.Money {
+	var tax, lower model.Money
+	for _, bracket := range brackets {
+		if pkp <= lower {
+			break
+		}
+
+		upper := pkp
+		if bracket.UpTo > 0 && bracket.UpTo < pkp {
+			upper = bracket.UpTo
+		}
+		tax += (upper - lower).Mul(bracket.Rate, 1)
+		lower = upper
+	}
+
+	return tax
+}
@@ -0,0 +1,223 @@
+package payroll
+
+import (
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"testing"
+	"time"
+)
+
+func loadRateTable(t *testing.T, period time.Time) *RateTable {
+	t.Helper()
+
+	tables, err := LoadRateTables("../../payroll_rates.yml")
+	if err != nil {
+		t.Fatalf("LoadRateTables() error = %v", err)
+	}
+
+	table, err := tables.ForPeriod(period)
+	if err != nil {
+		t.Fatalf("ForPeriod(%s) error = %v", period.Format(model.PayrollPeriodLayout), err)
+	}
+
+	return table
+}
+
+func findLine(lines []*model.PayslipLine, code string) model.Money {
+	for _, line := range lines {
+		if line.Code == code {
+			return line.Amount
+		}
+	}
+
+	return 0
+}
+
+func TestCalculate_TER(t *testing.T) {
+	table := loadRateTable(t, time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC))
+
+	// a salary of 10,500,000 is a gross income of 10,976,700 with the Kesehatan, JKK and JKM of the company
+	tests := []struct {
+		status   model.PTKPStatus
+		category model.TERCategory
+		rate     float64
+		pph21    model.Money
+	}{
+		{status: model.PTKPStatusTK0, category: model.TERCategoryA, rate: 0.03, pph21: model.NewMoney(329301)},
+		{status: model.PTKPStatusTK1, category: model.TERCategoryA, rate: 0.03, pph21: model.NewMoney(329301)},
+		{status: model.PTKPStatusK0, category: model.TERCategoryA, rate: 0.03, pph21: model.NewMoney(329301)},
+		{status: model.PTKPStatusTK2, category: model.TERCategoryB, rate: 0.02, pph21: model.NewMoney(219534)},
+		{status: model.PTKPStatusTK3, category: model.TERCategoryB, rate: 0.02, pph21: model.NewMoney(219534)},
+		{status: model.PTKPStatusK1, category: model.TERCategoryB, rate: 0.02, pph21: model.NewMoney(219534)},
+		{status: model.PTKPStatusK2, category: model.TERCategoryB, rate: 0.02, pph21: model.NewMoney(219534)},
+		{status: model.PTKPStatusK3, category: model.TERCategoryC, rate: 0.0175, pph21: model.NewMoney(192092)},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			profile := &model.PayrollProfile{PTKPStatus: tt.status}
+			payslip := Calculate(table, time.October, model.NewMoney(10500000), profile, YearToDate{})
+
+			if payslip.TaxableIncome != model.NewMoney(10976700) {
+				t.Errorf("TaxableIncome = %s, want 10976700.00", payslip.TaxableIncome)
+			}
+			if payslip.TERCategory != tt.category {
+				t.Errorf("TERCategory = %s, want %s", payslip.TERCategory, tt.category)
+			}
+			if payslip.TERRate != tt.rate {
+				t.Errorf("TERRate = %v, want %v", payslip.TERRate, tt.rate)
+			}
+			if payslip.PPh21 != tt.pph21 {
+				t.Errorf("PPh21 = %s, want %s", payslip.PPh21, tt.pph21)
+			}
+			if got := findLine(payslip.Deductions, CodePPh21); got != tt.pph21 {
+				t.Errorf("the pph21 deduction = %s, want %s", got, tt.pph21)
+			}
+			if payslip.NetPay != payslip.GrossPay-payslip.TotalDeductions {
+				t.Errorf("NetPay = %s, want the gross pay %s minus the deductions %s", payslip.NetPay, payslip.GrossPay, payslip.TotalDeductions)
+			}
+		})
+	}
+}
+
+func TestCalculate_BPJSCaps(t *testing.T) {
+	type amounts struct {
+		employee, employer model.Money
+	}
+
+	tests := []struct {
+		name   string
+		period time.Time
+		salary model.Money
+		want   map[string]amounts
+	}{
+		{
+			name:   "under the caps",
+			period: time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC),
+			salary: model.NewMoney(10000000),
+			want: map[string]amounts{
+				CodeBPJSKesehatan: {employee: model.NewMoney(100000), employer: model.NewMoney(400000)},
+				CodeBPJSJHT:       {employee: model.NewMoney(200000), employer: model.NewMoney(370000)},
+				CodeBPJSJP:        {employee: model.NewMoney(100000), employer: model.NewMoney(200000)},
+				CodeBPJSJKK:       {employer: model.NewMoney(24000)},
+				CodeBPJSJKM:       {employer: model.NewMoney(30000)},
+			},
+		},
+		{
+			name:   "over the caps of 2025.1",
+			period: time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC),
+			salary: model.NewMoney(20000000),
+			want: map[string]amounts{
+				CodeBPJSKesehatan: {employee: model.NewMoney(120000), employer: model.NewMoney(480000)},
+				CodeBPJSJHT:       {employee: model.NewMoney(400000), employer: model.NewMoney(740000)},
+				CodeBPJSJP:        {employee: model.NewMoney(105474), employer: model.NewMoney(210948)},
+				CodeBPJSJKK:       {employer: model.NewMoney(48000)},
+				CodeBPJSJKM:       {employer: model.NewMoney(60000)},
+			},
+		},
+		{
+			name:   "over the caps of 2024.1",
+			period: time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC),
+			salary: model.NewMoney(20000000),
+			want: map[string]amounts{
+				CodeBPJSKesehatan: {employee: model.NewMoney(120000), employer: model.NewMoney(480000)},
+				CodeBPJSJHT:       {employee: model.NewMoney(400000), employer: model.NewMoney(740000)},
+				CodeBPJSJP:        {employee: model.NewMoney(100423), employer: model.NewMoney(200846)},
+				CodeBPJSJKK:       {employer: model.NewMoney(48000)},
+				CodeBPJSJKM:       {employer: model.NewMoney(60000)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := loadRateTable(t, tt.period)
+			payslip := Calculate(table, tt.period.Month(), tt.salary, model.NewDefaultPayrollProfile(1), YearToDate{})
+
+			var totalEmployer model.Money
+			for code, want := range tt.want {
+				if got := findLine(payslip.Deductions, code); got != want.employee {
+					t.Errorf("the %s of the employee = %s, want %s", code, got, want.employee)
+				}
+				if got := findLine(payslip.EmployerContributions, code); got != want.employer {
+					t.Errorf("the %s of the company = %s, want %s", code, got, want.employer)
+				}
+				totalEmployer += want.employer
+			}
+
+			if payslip.TotalEmployerContributions != totalEmployer {
+				t.Errorf("TotalEmployerContributions = %s, want %s", payslip.TotalEmployerContributions, totalEmployer)
+			}
+		})
+	}
+}
+
+func TestCalculate_DecemberReconciliation(t *testing.T) {
+	table := loadRateTable(t, time.Date(2026, time.December, 1, 0, 0, 0, 0, time.UTC))
+
+	// yearToDate sum the payslips of January to November with the same salary and status
+	yearToDate := func(salary model.Money, status model.PTKPStatus) YearToDate {
+		var sum YearToDate
+		for month := time.January; month < time.December; month++ {
+			table := loadRateTable(t, time.Date(2026, month, 1, 0, 0, 0, 0, time.UTC))
+			sum.Add(Calculate(table, month, salary, &model.PayrollProfile{PTKPStatus: status}, YearToDate{}))
+		}
+
+		return sum
+	}
+
+	tests := []struct {
+		name       string
+		status     model.PTKPStatus
+		salary     model.Money
+		yearToDate YearToDate
+		pph21      model.Money
+	}{
+		{
+			// the tax of the year is 3,277,200 and 11 months of 261,350 were withheld at the TER A rate of 2.5%
+			name:       "TK/0 the whole year",
+			status:     model.PTKPStatusTK0,
+			salary:     model.NewMoney(10000000),
+			yearToDate: yearToDate(model.NewMoney(10000000), model.PTKPStatusTK0),
+			pph21:      model.NewMoney(402350),
+		},
+		{
+			// the tax of the year is 2,192,400 and 11 months of 156,810 were withheld at the TER C rate of 1.5%
+			name:       "K/3 the whole year",
+			status:     model.PTKPStatusK3,
+			salary:     model.NewMoney(10000000),
+			yearToDate: yearToDate(model.NewMoney(10000000), model.PTKPStatusK3),
+			pph21:      model.NewMoney(467490),
+		},
+		{
+			// the yearly net income of a single month is under the PTKP
+			name:   "joined in December",
+			status: model.PTKPStatusTK0,
+			salary: model.NewMoney(10000000),
+			pph21:  0,
+		},
+		{
+			name:   "overpaid during the year",
+			status: model.PTKPStatusTK0,
+			salary: model.NewMoney(5000000),
+			yearToDate: YearToDate{
+				TaxableIncome:        model.NewMoney(5000000),
+				PensionContributions: model.NewMoney(150000),
+				PPh21:                model.NewMoney(1000000),
+			},
+			pph21: model.NewMoney(-1000000),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payslip := Calculate(table, time.December, tt.salary, &model.PayrollProfile{PTKPStatus: tt.status}, tt.yearToDate)
+
+			if payslip.PPh21 != tt.pph21 {
+				t.Errorf("PPh21 = %s, want %s", payslip.PPh21, tt.pph21)
+			}
+			if payslip.TERCategory != "" || payslip.TERRate != 0 {
+				t.Errorf("TERCategory = %q and TERRate = %v, want none in December", payslip.TERCategory, payslip.TERRate)
+			}
+			if payslip.NetPay != payslip.GrossPay-payslip.TotalDeductions {
+				t.Errorf("NetPay = %s, want the gross pay %s minus the deductions %s", payslip.NetPay, payslip.GrossPay, payslip.TotalDeductions)
+			}
+		})
+	}
+}
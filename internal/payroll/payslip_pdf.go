@@ -0,0 +1,154 @@
+package payroll
+
+import (
+	"fmt"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/jung-kurt/gofpdf"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// the layout of the payslip, in millimeters on an A4 page
+const (
+	pdfMargin      = 15.0
+	pdfLabelWidth  = 120.0
+	pdfAmountWidth = 60.0
+	pdfLineHeight  = 7.0
+)
+
+// brandColor the color of the header band and the section titles
+var brandColor = [3]int{0, 82, 147}
+
+var indonesianMonths = [...]string{
+	"Januari", "Februari", "Maret", "April", "Mei", "Juni",
+	"Juli", "Agustus", "September", "Oktober", "November", "Desember",
+}
+
+// WritePayslipPDF render the payslip as an A4 PDF branded with the company name, with Indonesian labels and
+// the amounts in Rupiah
+func WritePayslipPDF(w io.Writer, payslip *model.Payslip, companyName string) error {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin)
+	pdf.SetTitle(fmt.Sprintf("Slip Gaji %s %s", payslip.EmployeeName, payslip.Period), true)
+	pdf.SetAuthor(companyName, true)
+	// the core fonts are cp1252, the names are translated from UTF-8
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pdfMargin - 5)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.SetTextColor(128, 128, 128)
+		pdf.CellFormat(0, 5, tr(fmt.Sprintf("Dokumen ini dibuat secara otomatis oleh %s dan sah tanpa tanda tangan.", companyName)), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	// header band
+	pdf.SetFillColor(brandColor[0], brandColor[1], brandColor[2])
+	pdf.Rect(0, 0, 210, 30, "F")
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetXY(pdfMargin, 8)
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(pdfLabelWidth, 8, tr(companyName), "", 0, "L", false, 0, "")
+	pdf.CellFormat(pdfAmountWidth, 8, "SLIP GAJI", "", 1, "R", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.SetX(pdfMargin)
+	pdf.CellFormat(pdfLabelWidth+pdfAmountWidth, 6, "Periode "+formatPeriod(payslip.Period), "", 1, "R", false, 0, "")
+
+	// employee
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetY(38)
+	rows := [][2]string{
+		{"Nama", payslip.EmployeeName},
+		{"ID Karyawan", fmt.Sprintf("%d", payslip.EmployeeID)},
+		{"Jabatan", payslip.Position},
+		{"Status PTKP", string(payslip.PTKPStatus)},
+		{"Versi Tarif", payslip.RateTableVersion},
+	}
+	// the salary paid in another currency is converted into the Gaji Pokok at the rate of the period
+	if payslip.Salary != nil && payslip.SalaryCurrency != "" {
+		rows = append(rows,
+			[2]string{"Gaji", payslip.SalaryCurrency + " " + payslip.Salary.String()},
+			[2]string{"Kurs", fmt.Sprintf("1 %s = %s", payslip.SalaryCurrency, model.NewMoney(payslip.ExchangeRate).Rupiah())},
+		)
+	}
+	for _, row := range rows {
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.CellFormat(35, 6, row[0], "", 0, "L", false, 0, "")
+		pdf.CellFormat(5, 6, ":", "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(0, 6, tr(row[1]), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	earnings := []*model.PayslipLine{{Name: "Gaji Pokok", Amount: payslip.BaseSalary}}
+	earnings = append(earnings, payslip.Allowances...)
+	writePayslipSection(pdf, tr, "PENDAPATAN", earnings, "Total Pendapatan", payslip.GrossPay)
+	writePayslipSection(pdf, tr, "POTONGAN", payslip.Deductions, "Total Potongan", payslip.TotalDeductions)
+
+	// net pay
+	pdf.SetFillColor(brandColor[0], brandColor[1], brandColor[2])
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.CellFormat(pdfLabelWidth, pdfLineHeight+2, "  GAJI BERSIH (TAKE HOME PAY)", "", 0, "L", true, 0, "")
+	pdf.CellFormat(pdfAmountWidth, pdfLineHeight+2, payslip.NetPay.Rupiah()+"  ", "", 1, "R", true, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+	pdf.Ln(6)
+
+	writePayslipSection(pdf, tr, "KONTRIBUSI PERUSAHAAN", payslip.EmployerContributions, "Total Kontribusi Perusahaan", payslip.TotalEmployerContributions)
+
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.SetTextColor(80, 80, 80)
+	pdf.CellFormat(pdfLabelWidth, 5, "Penghasilan bruto PPh 21 sebulan", "", 0, "L", false, 0, "")
+	pdf.CellFormat(pdfAmountWidth, 5, payslip.TaxableIncome.Rupiah(), "", 1, "R", false, 0, "")
+	switch {
+	case payslip.TERCategory != "":
+		pdf.CellFormat(pdfLabelWidth, 5, "Tarif efektif rata-rata (TER) kategori "+string(payslip.TERCategory), "", 0, "L", false, 0, "")
+		pdf.CellFormat(pdfAmountWidth, 5, strings.Replace(strconv.FormatFloat(payslip.TERRate*100, 'f', 2, 64), ".", ",", 1)+"%", "", 1, "R", false, 0, "")
+	case strings.HasSuffix(payslip.Period, "-12"):
+		pdf.CellFormat(pdfLabelWidth, 5, "PPh 21 Desember: pajak setahun dikurangi PPh 21 Januari sampai November.", "", 1, "L", false, 0, "")
+	}
+	pdf.CellFormat(pdfLabelWidth, 5, "Kontribusi perusahaan tidak dipotong dari gaji karyawan.", "", 1, "L", false, 0, "")
+
+	return pdf.Output(w)
+}
+
+// PayslipFileName return the name of the PDF file of the payslip, e.g. payslip-2026-10-42.pdf
+func PayslipFileName(payslip *model.Payslip) string {
+	return fmt.Sprintf("payslip-%s-%d.pdf", payslip.Period, payslip.EmployeeID)
+}
+
+// writePayslipSection write a titled list of amounts followed by its total
+func writePayslipSection(pdf *gofpdf.Fpdf, tr func(string) string, title string, lines []*model.PayslipLine, totalLabel string, total model.Money) {
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.SetTextColor(brandColor[0], brandColor[1], brandColor[2])
+	pdf.CellFormat(pdfLabelWidth+pdfAmountWidth, pdfLineHeight, title, "B", 1, "L", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+
+	pdf.SetFont("Helvetica", "", 10)
+	if len(lines) == 0 {
+		pdf.CellFormat(pdfLabelWidth, pdfLineHeight, "-", "", 0, "L", false, 0, "")
+		pdf.CellFormat(pdfAmountWidth, pdfLineHeight, model.Money(0).Rupiah(), "", 1, "R", false, 0, "")
+	}
+	for _, line := range lines {
+		pdf.CellFormat(pdfLabelWidth, pdfLineHeight, tr(line.Name), "", 0, "L", false, 0, "")
+		pdf.CellFormat(pdfAmountWidth, pdfLineHeight, line.Amount.Rupiah(), "", 1, "R", false, 0, "")
+	}
+
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(pdfLabelWidth, pdfLineHeight, totalLabel, "T", 0, "L", false, 0, "")
+	pdf.CellFormat(pdfAmountWidth, pdfLineHeight, total.Rupiah(), "T", 1, "R", false, 0, "")
+	pdf.Ln(4)
+}
+
+// formatPeriod format a YYYY-MM period as its Indonesian month name and year, e.g. Oktober 2026
+func formatPeriod(period string) string {
+	start, err := time.Parse(model.PayrollPeriodLayout, period)
+	if err != nil {
+		return period
+	}
+
+	return fmt.Sprintf("%s %d", indonesianMonths[start.Month()-1], start.Year())
+}
@@ -0,0 +1,168 @@
+package payroll
+
+import (
+	"errors"
+	"fmt"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"gopkg.in/yaml.v3"
+	"os"
+	"sort"
+	"time"
+)
+
+// ErrNoRateTable returned when no rate table is effective for a period
+var ErrNoRateTable = errors.New("no rate table effective for the period")
+
+// ContributionRate the rates of a BPJS program on the monthly wage, capped at SalaryCap when it is set
+type ContributionRate struct {
+	EmployeeRate float64     `yaml:"employee_rate"`
+	EmployerRate float64     `yaml:"employer_rate"`
+	SalaryCap    model.Money `yaml:"salary_cap"`
+}
+
+// base return the wage the rates apply to
+func (c ContributionRate) base(wage model.Money) model.Money {
+	if c.SalaryCap > 0 && wage > c.SalaryCap {
+		return c.SalaryCap
+	}
+
+	return wage
+}
+
+// TaxBracket a PPh 21 bracket, UpTo is zero for the last unbounded one. The yearly brackets are progressive,
+// while the whole monthly gross income is taxed at the rate of its TER bracket.
+type TaxBracket struct {
+	UpTo model.Money `yaml:"up_to"`
+	Rate float64     `yaml:"rate"`
+}
+
+// PositionCost the biaya jabatan deducted from the gross income before tax, capped at 12 times the monthly cap
+// on a year
+type PositionCost struct {
+	Rate       float64     `yaml:"rate"`
+	MonthlyCap model.Money `yaml:"monthly_cap"`
+}
+
+// RateTable a version of the payroll rates, effective from a day until the next version
+type RateTable struct {
+	Version       string                                 `yaml:"version"`
+	EffectiveFrom string                                 `yaml:"effective_from"`
+	PTKP          map[model.PTKPStatus]model.Money       `yaml:"ptkp"`
+	TaxBrackets   []TaxBracket                           `yaml:"tax_brackets"`
+	TERCategories map[model.PTKPStatus]model.TERCategory `yaml:"ter_categories"`
+	TER           map[model.TERCategory][]TaxBracket     `yaml:"ter"`
+	PositionCost  PositionCost                           `yaml:"position_cost"`
+	BPJSKesehatan ContributionRate                       `yaml:"bpjs_kesehatan"`
+	BPJSJHT       ContributionRate                       `yaml:"bpjs_jht"`
+	BPJSJP        ContributionRate                       `yaml:"bpjs_jp"`
+	BPJSJKK       ContributionRate                       `yaml:"bpjs_jkk"`
+	BPJSJKM       ContributionRate                       `yaml:"bpjs_jkm"`
+	effectiveFrom time.Time
+}
+
+// ptkpStatuses every PTKP status, a table must set the PTKP and the TER category of each
+var ptkpStatuses = []model.PTKPStatus{
+	model.PTKPStatusTK0, model.PTKPStatusTK1, model.PTKPStatusTK2, model.PTKPStatusTK3,
+	model.PTKPStatusK0, model.PTKPStatusK1, model.PTKPStatusK2, model.PTKPStatusK3,
+}
+
+// validate check the table is usable and parse its effective date
+func (t *RateTable) validate() error {
+	if t.Version == "" {
+		return errors.New("missing version")
+	}
+
+	effectiveFrom, err := time.Parse(model.SalaryChangeDateLayout, t.EffectiveFrom)
+	if err != nil {
+		return fmt.Errorf("rate table %s: invalid effective_from: %w", t.Version, err)
+	}
+	t.effectiveFrom = effectiveFrom
+
+	if err := validateBrackets(t.TaxBrackets); err != nil {
+		return fmt.Errorf("rate table %s: tax_brackets: %w", t.Version, err)
+	}
+
+	for _, status := range ptkpStatuses {
+		if _, ok := t.PTKP[status]; !ok {
+			return fmt.Errorf("rate table %s: missing the ptkp of %s", t.Version, status)
+		}
+
+		category, ok := t.TERCategories[status]
+		if !ok {
+			return fmt.Errorf("rate table %s: missing the ter category of %s", t.Version, status)
+		}
+
+		if err := validateBrackets(t.TER[category]); err != nil {
+			return fmt.Errorf("rate table %s: ter %s: %w", t.Version, category, err)
+		}
+	}
+
+	return nil
+}
+
+// validateBrackets check the brackets are ascending and only the last one is unbounded
+func validateBrackets(brackets []TaxBracket) error {
+	if len(brackets) == 0 {
+		return errors.New("missing brackets")
+	}
+
+	for idx, bracket := range brackets {
+		last := idx == len(brackets)-1
+		if last != (bracket.UpTo == 0) {
+			return errors.New("only the last bracket must be unbounded")
+		}
+
+		if idx > 0 && !last && bracket.UpTo <= brackets[idx-1].UpTo {
+			return errors.New("the brackets must be ascending")
+		}
+	}
+
+	return nil
+}
+
+// RateTables the versions of the rates, ordered by effective date
+type RateTables []*RateTable
+
+// LoadRateTables read and check the rate tables of the YAML file
+func LoadRateTables(path string) (RateTables, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file struct {
+		RateTables RateTables `yaml:"rate_tables"`
+	}
+	if err := yaml.Unmarshal(b, &file); err != nil {
+		return nil, err
+	}
+
+	if len(file.RateTables) == 0 {
+		return nil, errors.New("no rate table")
+	}
+
+	for _, table := range file.RateTables {
+		if err := table.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	tables := file.RateTables
+	sort.SliceStable(tables, func(i, j int) bool {
+		return tables[i].effectiveFrom.Before(tables[j].effectiveFrom)
+	})
+
+	return tables, nil
+}
+
+// ForPeriod return the latest table effective on the first day of the period,
+// ErrNoRateTable is returned when the period is before every table
+func (r RateTables) ForPeriod(period time.Time) (*RateTable, error) {
+	for idx := len(r) - 1; idx >= 0; idx-- {
+		if !r[idx].effectiveFrom.After(period) {
+			return r[idx], nil
+		}
+	}
+
+	return nil, ErrNoRateTable
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/irvankadhafi/employee-api/utils"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"strconv"
+	"strings"
 )
 
 func storeNil(ck cacher.CacheManager, key string) {
@@ -16,6 +18,26 @@ func storeNil(ck cacher.CacheManager, key string) {
 	}
 }
 
+// findCacheGeneration return the generation of a namespace of cache keys, 0 until it is first bumped.
+// The generation is part of the keys so a write drops them all by bumping it, without scanning the keyspace.
+func findCacheGeneration(ck cacher.CacheManager, key string) (int64, error) {
+	reply, err := ck.Get(key)
+	if err != nil || reply == nil {
+		return 0, err
+	}
+
+	generation, _ := reply.([]byte)
+	return strconv.ParseInt(string(generation), 10, 64)
+}
+
+// bumpCacheGeneration start a new generation of a namespace of cache keys, the keys of the previous one are
+// never read again and expire with their TTL
+func bumpCacheGeneration(ck cacher.CacheManager, key string) {
+	if err := ck.IncreaseCachedValueByOne(key); err != nil {
+		logrus.Error(err)
+	}
+}
+
 // scopeByPageAndLimit is a helper function to apply pagination on gorm query.
 // it takes in 2 input as page and limit and returns a scope function
 // that can be passed to gorm's db.Scopes method
@@ -49,3 +71,26 @@ func withSize(size int64) func(db *gorm.DB) *gorm.DB {
 		return db.Limit(int(size))
 	}
 }
+
+// escapeLike escape the wildcards of a LIKE pattern, so the value is matched literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// sameID check whether two nullable IDs are equal, both nil included
+func sameID(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+func toAnySlice[T any](items []T) []any {
+	result := make([]any, len(items))
+	for idx, item := range items {
+		result[idx] = item
+	}
+
+	return result
+}
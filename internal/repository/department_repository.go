@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/irvankadhafi/employee-api/cacher"
+	"github.com/irvankadhafi/employee-api/internal/config"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/irvankadhafi/employee-api/utils"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type departmentRepository struct {
+	db           *gorm.DB
+	cacheManager cacher.CacheManager
+}
+
+func NewDepartmentRepository(db *gorm.DB, cacheManager cacher.CacheManager) model.DepartmentRepository {
+	return &departmentRepository{
+		db:           db,
+		cacheManager: cacheManager,
+	}
+}
+
+func (d *departmentRepository) Create(ctx context.Context, department *model.Department) error {
+	err := d.db.WithContext(ctx).Create(department).Error
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":        utils.DumpIncomingContext(ctx),
+			"department": utils.Dump(department),
+		}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (d *departmentRepository) FindByID(ctx context.Context, id int64) (*model.Department, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.DumpIncomingContext(ctx),
+		"id":  id,
+	})
+
+	cacheKey := d.newCacheKeyByID(id)
+	if !config.DisableCaching() {
+		reply, mu, err := findFromCacheByKey[*model.Department](d.cacheManager, cacheKey)
+		defer cacher.SafeUnlock(mu)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		if mu == nil {
+			return reply, nil
+		}
+	}
+
+	department := &model.Department{}
+	err := d.db.WithContext(ctx).Take(department, "id = ?", id).Error
+	switch err {
+	case nil:
+	case gorm.ErrRecordNotFound:
+		storeNil(d.cacheManager, cacheKey)
+		return nil, nil
+	default:
+		logger.Error(err)
+		return nil, err
+	}
+
+	err = d.cacheManager.StoreWithoutBlocking(cacher.NewItem(cacheKey, utils.Dump(department)))
+	if err != nil {
+		logger.Error(err)
+	}
+
+	return department, nil
+}
+
+// FindByName find the department by its name, case-insensitively
+func (d *departmentRepository) FindByName(ctx context.Context, name string) (*model.Department, error) {
+	department := &model.Department{}
+	err := d.db.WithContext(ctx).Take(department, "lower(name) = lower(?)", name).Error
+	switch err {
+	case nil:
+		return department, nil
+	case gorm.ErrRecordNotFound:
+		return nil, nil
+	default:
+		logrus.WithFields(logrus.Fields{
+			"ctx":  utils.DumpIncomingContext(ctx),
+			"name": name,
+		}).Error(err)
+		return nil, err
+	}
+}
+
+func (d *departmentRepository) FindAllByCriteria(ctx context.Context, criteria model.DepartmentCriteria) (departments []*model.Department, count int64, err error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      utils.DumpIncomingContext(ctx),
+		"criteria": utils.Dump(criteria),
+	})
+
+	var scopes []func(*gorm.DB) *gorm.DB
+	if criteria.Name != "" {
+		scopes = append(scopes, func(db *gorm.DB) *gorm.DB {
+			return db.Where("name ILIKE ?", "%"+escapeLike(criteria.Name)+"%")
+		})
+	}
+
+	err = d.db.WithContext(ctx).Model(&model.Department{}).Scopes(scopes...).Count(&count).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	if count <= 0 {
+		return nil, 0, nil
+	}
+
+	err = d.db.WithContext(ctx).
+		Scopes(scopes...).
+		Scopes(scopeByPageAndLimit(criteria.Page, criteria.Size)).
+		Order("name ASC, id ASC").
+		Find(&departments).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	return departments, count, nil
+}
+
+func (d *departmentRepository) Update(ctx context.Context, department *model.Department) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":        utils.DumpIncomingContext(ctx),
+		"department": utils.Dump(department),
+	})
+
+	err := d.db.WithContext(ctx).Model(&model.Department{}).
+		Where("id = ?", department.ID).
+		Updates(map[string]any{
+			"name":        department.Name,
+			"description": department.Description,
+		}).Error
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if err := d.cacheManager.DeleteByKeys([]string{d.newCacheKeyByID(department.ID)}); err != nil {
+		logger.Error(err)
+	}
+
+	return nil
+}
+
+// Delete soft delete the department, model.ErrDepartmentHasMembers is returned while an employee belongs to it.
+// The department row is locked, so an employee can't be moved in between the check and the delete.
+func (d *departmentRepository) Delete(ctx context.Context, id int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.DumpIncomingContext(ctx),
+		"id":  id,
+	})
+
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		department := &model.Department{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(department, "id = ?", id).Error
+		if err != nil {
+			return err
+		}
+
+		var members int64
+		err = tx.Model(&model.Employee{}).Where("department_id = ?", id).Count(&members).Error
+		if err != nil {
+			return err
+		}
+
+		if members > 0 {
+			return model.ErrDepartmentHasMembers
+		}
+
+		return tx.Model(&model.Department{}).Where("id = ?", id).Update("deleted_at", time.Now()).Error
+	})
+	switch err {
+	case nil:
+	case model.ErrDepartmentHasMembers:
+		return err
+	default:
+		logger.Error(err)
+		return err
+	}
+
+	if err := d.cacheManager.DeleteByKeys([]string{d.newCacheKeyByID(id)}); err != nil {
+		logger.Error(err)
+	}
+
+	return nil
+}
+
+func (d *departmentRepository) newCacheKeyByID(id int64) string {
+	return fmt.Sprintf("cache:object:department:id:%d", id)
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/irvankadhafi/employee-api/internal/auth"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/irvankadhafi/employee-api/utils"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"reflect"
+)
+
+// systemActor is recorded as the actor when there is no authenticated user in the context
+const systemActor = "system"
+
+// unauditedEmployeeFields are excluded from the audit log diff
+var unauditedEmployeeFields = []string{"id", "version", "created_at", "updated_at", "deleted_at"}
+
+func (e *employeeRepository) FindAuditLogsByCriteria(ctx context.Context, criteria model.EmployeeAuditLogCriteria) (logs []*model.EmployeeAuditLog, count int64, err error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      utils.DumpIncomingContext(ctx),
+		"criteria": utils.DumpRedacted(criteria),
+	})
+
+	err = e.db.WithContext(ctx).Model(model.EmployeeAuditLog{}).
+		Where("employee_id = ?", criteria.EmployeeID).
+		Count(&count).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	if count <= 0 {
+		return nil, 0, nil
+	}
+
+	err = e.db.WithContext(ctx).
+		Where("employee_id = ?", criteria.EmployeeID).
+		Scopes(scopeByPageAndLimit(criteria.Page, criteria.Size)).
+		Order("created_at DESC, id DESC").
+		Find(&logs).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	return logs, count, nil
+}
+
+// createAuditLog write the audit log of the mutation using the given transaction,
+// before is nil for a creation and after is nil for a deletion
+func createAuditLog(ctx context.Context, tx *gorm.DB, action model.EmployeeAuditAction, employeeID int64, before, after *model.Employee) error {
+	beforeDiff, afterDiff, err := diffEmployee(before, after)
+	if err != nil {
+		return err
+	}
+
+	auditLog := &model.EmployeeAuditLog{
+		EmployeeID: employeeID,
+		Actor:      actorFromCtx(ctx),
+		Action:     action,
+		Before:     beforeDiff,
+		After:      afterDiff,
+	}
+
+	return tx.Create(auditLog).Error
+}
+
+// diffEmployee return the audited fields which differ between before and after
+func diffEmployee(before, after *model.Employee) (beforeDiff, afterDiff json.RawMessage, err error) {
+	beforeFields, err := toAuditedFields(before)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	afterFields, err := toAuditedFields(after)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if beforeFields != nil && afterFields != nil {
+		for key, value := range beforeFields {
+			if reflect.DeepEqual(value, afterFields[key]) {
+				delete(beforeFields, key)
+				delete(afterFields, key)
+			}
+		}
+	}
+
+	if beforeFields != nil {
+		beforeDiff = utils.ToByte(beforeFields)
+	}
+	if afterFields != nil {
+		afterDiff = utils.ToByte(afterFields)
+	}
+
+	return beforeDiff, afterDiff, nil
+}
+
+func toAuditedFields(employee *model.Employee) (map[string]any, error) {
+	if employee == nil {
+		return nil, nil
+	}
+
+	fields := map[string]any{}
+	if err := json.Unmarshal(utils.ToByte(employee), &fields); err != nil {
+		return nil, err
+	}
+
+	for _, field := range unauditedEmployeeFields {
+		delete(fields, field)
+	}
+
+	return fields, nil
+}
+
+func actorFromCtx(ctx context.Context) string {
+	user := auth.GetUserFromCtx(ctx)
+	if user == nil {
+		return systemActor
+	}
+
+	return user.ID
+}
@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/irvankadhafi/employee-api/cacher"
+	"github.com/irvankadhafi/employee-api/internal/config"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/irvankadhafi/employee-api/utils"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// orgChartLockKey is the advisory lock serializing the manager changes, two concurrent changes could
+// otherwise each pass the cycle check and together form a cycle
+const orgChartLockKey = 7_240_001
+
+// orgChartCacheGenerationKey the generation of the reporting tree cache keys, see findCacheGeneration
+const orgChartCacheGenerationKey = "cache:org-chart:generation"
+
+// reportingTreeSQL walks down the reporting lines from the anchor employees up to a depth.
+// The path guards against a cycle in the data, which the writes never create.
+const reportingTreeSQL = `
+WITH RECURSIVE tree AS (
+	SELECT id, 0 AS depth, ARRAY[id] AS path FROM employees e WHERE e.deleted_at IS NULL AND (%s)
+	UNION ALL
+	SELECT e.id, tree.depth + 1, tree.path || e.id
+	FROM employees e JOIN tree ON e.manager_id = tree.id
+	WHERE e.deleted_at IS NULL AND tree.depth < @depth AND NOT e.id = ANY(tree.path)
+)
+SELECT e.id, e.name, e.position, e.department_id, e.manager_id, tree.depth,
+	(SELECT COUNT(*) FROM employees r WHERE r.manager_id = e.id AND r.deleted_at IS NULL) AS direct_reports
+FROM tree JOIN employees e ON e.id = tree.id
+ORDER BY tree.depth, e.name, e.id`
+
+// managerChainSQL walks up the reporting lines from an employee, the employee included
+const managerChainSQL = `
+WITH RECURSIVE chain AS (
+	SELECT id, manager_id, ARRAY[id] AS path FROM employees WHERE id = @id AND deleted_at IS NULL
+	UNION ALL
+	SELECT e.id, e.manager_id, chain.path || e.id
+	FROM employees e JOIN chain ON e.id = chain.manager_id
+	WHERE e.deleted_at IS NULL AND NOT e.id = ANY(chain.path)
+)
+SELECT id FROM chain`
+
+type orgChartRow struct {
+	model.OrgChartNode
+	Depth int
+}
+
+// FindReportingTree find the employee with its reports down to depth levels, nil when the employee doesn't exist.
+// The tree is cached for config.OrgChartCacheTTL and dropped on any change of the employees by bumping the
+// reporting tree cache generation.
+func (e *employeeRepository) FindReportingTree(ctx context.Context, employeeID int64, depth int) (*model.OrgChartNode, error) {
+	nodes, err := e.findOrgChart(ctx, fmt.Sprint(employeeID), "e.id = @id", employeeID, depth)
+	if err != nil || len(nodes) == 0 {
+		return nil, err
+	}
+
+	return nodes[0], nil
+}
+
+// FindOrgChart find the trees of the employees without a manager down to depth levels. An employee whose manager
+// is deleted is a root as well. The chart is cached like FindReportingTree.
+func (e *employeeRepository) FindOrgChart(ctx context.Context, depth int) ([]*model.OrgChartNode, error) {
+	anchor := "NOT EXISTS (SELECT 1 FROM employees m WHERE m.id = e.manager_id AND m.deleted_at IS NULL)"
+	return e.findOrgChart(ctx, "root", anchor, 0, depth)
+}
+
+// FindManagerChain return the IDs of the employee and of its managers, up to the top of the reporting line
+func (e *employeeRepository) FindManagerChain(ctx context.Context, employeeID int64) ([]int64, error) {
+	ids, err := findManagerChain(e.db.WithContext(ctx), employeeID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":        utils.DumpIncomingContext(ctx),
+			"employeeID": employeeID,
+		}).Error(err)
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (e *employeeRepository) findOrgChart(ctx context.Context, root, anchor string, employeeID int64, depth int) ([]*model.OrgChartNode, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":        utils.DumpIncomingContext(ctx),
+		"employeeID": employeeID,
+		"depth":      depth,
+	})
+
+	generation, err := findCacheGeneration(e.cacheManager, orgChartCacheGenerationKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	reply, err := e.cacheManager.GetOrSet(e.newOrgChartCacheKey(generation, root, depth), func() (any, error) {
+		var rows []*orgChartRow
+		err := e.db.WithContext(ctx).
+			Raw(fmt.Sprintf(reportingTreeSQL, anchor), map[string]any{"id": employeeID, "depth": depth}).
+			Scan(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+
+		return buildOrgChart(rows), nil
+	}, cacher.WithTTL(config.OrgChartCacheTTL()))
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	nodes := []*model.OrgChartNode{}
+	if reply == nil {
+		return nodes, nil
+	}
+
+	if err := json.Unmarshal(reply, &nodes); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return nodes, nil
+}
+
+// buildOrgChart nest the rows, sorted by depth, under their manager. The rows at depth zero are the roots.
+func buildOrgChart(rows []*orgChartRow) []*model.OrgChartNode {
+	roots := []*model.OrgChartNode{}
+	nodes := map[int64]*model.OrgChartNode{}
+	for _, row := range rows {
+		node := row.OrgChartNode
+		node.Reports = []*model.OrgChartNode{}
+		nodes[node.ID] = &node
+
+		if row.Depth == 0 || node.ManagerID == nil || nodes[*node.ManagerID] == nil {
+			roots = append(roots, &node)
+			continue
+		}
+
+		manager := nodes[*node.ManagerID]
+		manager.Reports = append(manager.Reports, &node)
+	}
+
+	return roots
+}
+
+func findManagerChain(db *gorm.DB, employeeID int64) ([]int64, error) {
+	var ids []int64
+	err := db.Raw(managerChainSQL, map[string]any{"id": employeeID}).Scan(&ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// checkManager lock the new manager of the employee and check it doesn't report to the employee,
+// the advisory lock is held until the transaction ends so the reporting lines can't change in between
+func checkManager(tx *gorm.DB, employeeID int64, managerID *int64) error {
+	if managerID == nil {
+		return nil
+	}
+
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", orgChartLockKey).Error; err != nil {
+		return err
+	}
+
+	chain, err := findManagerChain(tx, *managerID)
+	if err != nil {
+		return err
+	}
+
+	if len(chain) == 0 {
+		return model.ErrManagerNotFound
+	}
+
+	for _, id := range chain {
+		if id == employeeID {
+			return model.ErrManagerCycle
+		}
+	}
+
+	return nil
+}
+
+// deleteOrgChartCaches drop every cached reporting tree by bumping their generation, any of them may have changed
+func (e *employeeRepository) deleteOrgChartCaches() {
+	bumpCacheGeneration(e.cacheManager, orgChartCacheGenerationKey)
+}
+
+func (e *employeeRepository) newOrgChartCacheKey(generation int64, root string, depth int) string {
+	return fmt.Sprintf("cache:org-chart:%d:%s:%d", generation, root, depth)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/irvankadhafi/employee-api/cacher"
 	"github.com/irvankadhafi/employee-api/internal/config"
@@ -9,9 +10,15 @@ import (
 	"github.com/irvankadhafi/employee-api/utils"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"sort"
+	"strings"
 	"time"
 )
 
+// suggestCacheGenerationKey the generation of the suggestion cache keys, see findCacheGeneration
+const suggestCacheGenerationKey = "cache:suggest:employee:generation"
+
 type employeeRepository struct {
 	db           *gorm.DB
 	cacheManager cacher.CacheManager
@@ -64,16 +71,102 @@ func (e *employeeRepository) FindByID(ctx context.Context, id int64) (*model.Emp
 	return employee, nil
 }
 
+func (e *employeeRepository) FindByExternalKey(ctx context.Context, externalKey string) (*model.Employee, error) {
+	employee := &model.Employee{}
+	err := e.db.WithContext(ctx).Take(employee, "external_key = ?", externalKey).Error
+	switch err {
+	case nil:
+		return employee, nil
+	case gorm.ErrRecordNotFound:
+		return nil, nil
+	default:
+		logrus.WithFields(logrus.Fields{
+			"ctx":         utils.DumpIncomingContext(ctx),
+			"externalKey": externalKey,
+		}).Error(err)
+		return nil, err
+	}
+}
+
+// FindAllByIDsWithDeleted find the employees including the deleted ones, in no particular order.
+// The deleted employees are never cached, so the cache is bypassed.
+func (e *employeeRepository) FindAllByIDsWithDeleted(ctx context.Context, ids []int64) ([]*model.Employee, error) {
+	var employees []*model.Employee
+	err := e.db.WithContext(ctx).Unscoped().Where("id IN ?", ids).Find(&employees).Error
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.DumpIncomingContext(ctx),
+			"ids": ids,
+		}).Error(err)
+		return nil, err
+	}
+
+	return employees, nil
+}
+
 func (e *employeeRepository) Update(ctx context.Context, employee *model.Employee) (err error) {
 	logger := logrus.WithFields(logrus.Fields{
 		"ctx":      utils.DumpIncomingContext(ctx),
-		"employee": utils.Dump(employee),
+		"employee": utils.DumpRedacted(employee),
 	})
 
-	err = e.db.WithContext(ctx).Model(&model.Employee{}).
-		Where("id = ?", employee.ID).Select("name", "position", "salary").
-		Updates(employee).Error
-	if err != nil {
+	err = e.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		before, err := findByIDForUpdate(tx, employee.ID)
+		if err != nil {
+			return err
+		}
+
+		if before.Version != employee.Version {
+			return model.ErrVersionConflict
+		}
+
+		if err := lockPosition(tx, employee.Position); err != nil {
+			return err
+		}
+
+		if err := lockDepartment(tx, employee.DepartmentID); err != nil {
+			return err
+		}
+
+		if !sameID(before.ManagerID, employee.ManagerID) {
+			if err := checkManager(tx, employee.ID, employee.ManagerID); err != nil {
+				return err
+			}
+		}
+
+		res := tx.Model(&model.Employee{}).
+			Where("id = ? AND version = ?", employee.ID, employee.Version).
+			Updates(map[string]any{
+				"name":          employee.Name,
+				"position":      employee.Position,
+				"salary":        employee.Salary,
+				"currency":      employee.Currency,
+				"department_id": employee.DepartmentID,
+				"manager_id":    employee.ManagerID,
+				"version":       gorm.Expr("version + 1"),
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return model.ErrVersionConflict
+		}
+
+		if before.Salary != employee.Salary || before.Currency != employee.Currency {
+			if err := recordSalaryChange(ctx, tx, employee, salaryChangeReasonUpdated); err != nil {
+				return err
+			}
+		}
+
+		return createAuditLog(ctx, tx, model.EmployeeAuditActionUpdate, employee.ID, before, employee)
+	})
+	switch err {
+	case nil:
+		employee.Version++
+	case model.ErrVersionConflict:
+		return err
+	default:
 		logger.Error(err)
 		return err
 	}
@@ -83,28 +176,50 @@ func (e *employeeRepository) Update(ctx context.Context, employee *model.Employe
 	}); err != nil {
 		logger.Error(err)
 	}
+	e.deleteSuggestCaches()
+	e.deleteOrgChartCaches()
 
 	return nil
 }
 
-func (e *employeeRepository) Delete(ctx context.Context, employeeID int64) error {
+func (e *employeeRepository) Delete(ctx context.Context, employeeID int64, version int64) error {
 	logger := logrus.WithFields(logrus.Fields{
 		"ctx":        utils.DumpIncomingContext(ctx),
 		"employeeID": employeeID,
+		"version":    version,
 	})
 
-	employee := model.Employee{}
+	err := e.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		before, err := findByIDForUpdate(tx, employeeID)
+		if err != nil {
+			return err
+		}
 
-	err := employee.DeletedAt.Scan(time.Now())
-	if err != nil {
-		return err
-	}
+		if before.Version != version {
+			return model.ErrVersionConflict
+		}
 
-	err = e.db.WithContext(ctx).Model(&model.Employee{}).
-		Where("id = ?", employeeID).
-		Updates(employee).
-		Error
-	if err != nil {
+		res := tx.Model(&model.Employee{}).
+			Where("id = ? AND version = ?", employeeID, version).
+			Updates(map[string]any{
+				"deleted_at": time.Now(),
+				"version":    gorm.Expr("version + 1"),
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return model.ErrVersionConflict
+		}
+
+		return createAuditLog(ctx, tx, model.EmployeeAuditActionDelete, employeeID, before, nil)
+	})
+	switch err {
+	case nil:
+	case model.ErrVersionConflict:
+		return err
+	default:
 		logger.Error(err)
 		return err
 	}
@@ -115,6 +230,8 @@ func (e *employeeRepository) Delete(ctx context.Context, employeeID int64) error
 	if err != nil {
 		logger.Error(err)
 	}
+	e.deleteSuggestCaches()
+	e.deleteOrgChartCaches()
 
 	return nil
 }
@@ -122,7 +239,7 @@ func (e *employeeRepository) Delete(ctx context.Context, employeeID int64) error
 func (e *employeeRepository) SearchByPage(ctx context.Context, searchCriteria model.EmployeeSearchCriteria) (ids []int64, count int64, err error) {
 	logger := logrus.WithFields(logrus.Fields{
 		"ctx":            utils.DumpIncomingContext(ctx),
-		"searchCriteria": utils.Dump(searchCriteria),
+		"searchCriteria": utils.DumpRedacted(searchCriteria),
 	})
 
 	count, err = e.countAll(ctx, searchCriteria)
@@ -147,35 +264,95 @@ func (e *employeeRepository) SearchByPage(ctx context.Context, searchCriteria mo
 	}
 }
 
-func (e *employeeRepository) findAllIDsByCriteria(ctx context.Context, criteria model.EmployeeSearchCriteria) ([]int64, error) {
-	var scopes []func(*gorm.DB) *gorm.DB
-	scopes = append(scopes, scopeByPageAndLimit(criteria.Page, criteria.Size))
+// SearchByCursor find the IDs of the page following the cursor, ordered by the sort column then the ID.
+// Size+1 IDs are returned when there is a next page. The count is only done with criteria.WithCount.
+func (e *employeeRepository) SearchByCursor(ctx context.Context, criteria model.EmployeeSearchCriteria, cursor *model.EmployeeCursor) (ids []int64, count int64, err error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      utils.DumpIncomingContext(ctx),
+		"criteria": utils.DumpRedacted(criteria),
+	})
 
-	// Add LIKE query for name if provided
-	if criteria.Name != "" {
-		scopes = append(scopes, func(db *gorm.DB) *gorm.DB {
-			return db.Where("name ILIKE ?", "%"+criteria.Name+"%")
-		})
+	if criteria.WithCount {
+		count, err = e.countAll(ctx, criteria)
+		if err != nil {
+			logger.Error(err)
+			return nil, 0, err
+		}
+
+		if count <= 0 {
+			return nil, 0, nil
+		}
 	}
 
-	// Add filter for position if provided
-	if criteria.Position != "" {
-		scopes = append(scopes, func(db *gorm.DB) *gorm.DB {
-			return db.Where("position = ?", criteria.Position)
-		})
+	scopes := scopesByCriteria(criteria)
+	if cursor != nil {
+		scopes = append(scopes, scopeAfterCursor(cursor, salaryColumn(&criteria)))
+	}
+
+	err = e.db.WithContext(ctx).
+		Model(model.Employee{}).
+		Scopes(scopes...).
+		Order(orderBySort(criteria.Sort, salaryColumn(&criteria))).
+		Limit(int(criteria.Size)+1).
+		Pluck("id", &ids).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	return ids, count, nil
+}
+
+// SearchByQuery find the page of employees matching criteria.Query, ranked by relevance then ID
+func (e *employeeRepository) SearchByQuery(ctx context.Context, criteria model.EmployeeSearchCriteria) (hits []*model.EmployeeSearchHit, count int64, err error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      utils.DumpIncomingContext(ctx),
+		"criteria": utils.DumpRedacted(criteria),
+	})
+
+	count, err = e.countAll(ctx, criteria)
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
 	}
 
+	if count <= 0 {
+		return nil, 0, nil
+	}
+
+	err = e.db.WithContext(ctx).
+		Model(model.Employee{}).
+		Scopes(scopesByCriteria(criteria)...).
+		Scopes(scopeByPageAndLimit(criteria.Page, criteria.Size)).
+		Select("id, ? AS score", queryScoreExpr(criteria.Query)).
+		Order(clause.OrderBy{Columns: []clause.OrderByColumn{
+			{Column: clause.Column{Name: "score", Raw: true}, Desc: true},
+			{Column: clause.Column{Name: "id"}},
+		}}).
+		Find(&hits).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	return hits, count, nil
+}
+
+func (e *employeeRepository) findAllIDsByCriteria(ctx context.Context, criteria model.EmployeeSearchCriteria) ([]int64, error) {
+	scopes := scopesByCriteria(criteria)
+	scopes = append(scopes, scopeByPageAndLimit(criteria.Page, criteria.Size))
+
 	var ids []int64
 	err := e.db.WithContext(ctx).
 		Model(model.Employee{}).
 		Scopes(scopes...).
-		Order(fmt.Sprintf("%s %s", criteria.SortBy, criteria.SortDir)).
+		Order(orderBySort(criteria.Sort, salaryColumn(&criteria))).
 		Pluck("id", &ids).Error
 
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"ctx":      utils.DumpIncomingContext(ctx),
-			"criteria": utils.Dump(criteria),
+			"criteria": utils.DumpRedacted(criteria),
 		}).Error(err)
 		return nil, err
 	}
@@ -183,37 +360,244 @@ func (e *employeeRepository) findAllIDsByCriteria(ctx context.Context, criteria
 	return ids, nil
 }
 
+// FindAllAfterID find the next batch of employees matching the criteria filters, ordered by ID.
+// It seeks past afterID instead of using an offset, so walking a large table stays cheap.
+func (e *employeeRepository) FindAllAfterID(ctx context.Context, criteria model.EmployeeSearchCriteria, afterID int64, limit int) ([]*model.Employee, error) {
+	var employees []*model.Employee
+	err := e.db.WithContext(ctx).
+		Scopes(scopesByCriteria(criteria)...).
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&employees).Error
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":      utils.DumpIncomingContext(ctx),
+			"criteria": utils.DumpRedacted(criteria),
+			"afterID":  afterID,
+		}).Error(err)
+		return nil, err
+	}
+
+	return employees, nil
+}
+
+// FindReportingSalaries find the salary of each employee normalized with the rates of the criteria, computed by
+// the same expression the search filters, sorts and seeks on. An employee whose currency got no rate is left out.
+func (e *employeeRepository) FindReportingSalaries(ctx context.Context, criteria model.EmployeeSearchCriteria, ids []int64) (map[int64]model.Money, error) {
+	if criteria.Rates == nil || len(ids) == 0 {
+		return nil, nil
+	}
+
+	var rows []struct {
+		ID              int64
+		ReportingSalary *model.Money
+	}
+	err := e.db.WithContext(ctx).
+		Model(model.Employee{}).
+		Select("id, ? AS reporting_salary", reportingSalaryExpr(criteria.Rates, criteria.ReportingCurrency)).
+		Where("id IN ?", ids).
+		Find(&rows).Error
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":      utils.DumpIncomingContext(ctx),
+			"criteria": utils.DumpRedacted(criteria),
+			"ids":      ids,
+		}).Error(err)
+		return nil, err
+	}
+
+	salaries := make(map[int64]model.Money, len(rows))
+	for _, row := range rows {
+		if row.ReportingSalary != nil {
+			salaries[row.ID] = *row.ReportingSalary
+		}
+	}
+
+	return salaries, nil
+}
+
 func (e *employeeRepository) Create(ctx context.Context, employee *model.Employee) error {
 	logger := logrus.WithFields(logrus.Fields{
 		"ctx":      utils.DumpIncomingContext(ctx),
-		"employee": utils.Dump(employee),
+		"employee": utils.DumpRedacted(employee),
+	})
+
+	employee.Version = 1
+	err := e.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockPosition(tx, employee.Position); err != nil {
+			return err
+		}
+
+		if err := lockDepartment(tx, employee.DepartmentID); err != nil {
+			return err
+		}
+
+		if err := checkManager(tx, 0, employee.ManagerID); err != nil {
+			return err
+		}
+
+		if err := tx.Create(employee).Error; err != nil {
+			return err
+		}
+
+		if err := recordSalaryChange(ctx, tx, employee, salaryChangeReasonHired); err != nil {
+			return err
+		}
+
+		return createAuditLog(ctx, tx, model.EmployeeAuditActionCreate, employee.ID, nil, employee)
 	})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+	e.deleteSuggestCaches()
+	e.deleteOrgChartCaches()
+
+	return nil
+}
+
+// CreateInBatch create all the employees in one transaction, none is created if any of them fails
+func (e *employeeRepository) CreateInBatch(ctx context.Context, employees []*model.Employee) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":       utils.DumpIncomingContext(ctx),
+		"employees": utils.DumpRedacted(employees),
+	})
+
+	if len(employees) == 0 {
+		return nil
+	}
+
+	for _, employee := range employees {
+		employee.Version = 1
+	}
 
-	err := e.db.WithContext(ctx).Create(employee).Error
+	err := e.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, employee := range employees {
+			if err := lockPosition(tx, employee.Position); err != nil {
+				return err
+			}
+
+			if err := lockDepartment(tx, employee.DepartmentID); err != nil {
+				return err
+			}
+
+			if err := checkManager(tx, 0, employee.ManagerID); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Create(&employees).Error; err != nil {
+			return err
+		}
+
+		for _, employee := range employees {
+			if err := recordSalaryChange(ctx, tx, employee, salaryChangeReasonHired); err != nil {
+				return err
+			}
+
+			if err := createAuditLog(ctx, tx, model.EmployeeAuditActionCreate, employee.ID, nil, employee); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
 	if err != nil {
 		logger.Error(err)
 		return err
 	}
+	e.deleteSuggestCaches()
+	e.deleteOrgChartCaches()
 
 	return nil
 }
 
-func (e *employeeRepository) GetDistinctPositions(ctx context.Context) ([]string, error) {
-	var positions []string
-	err := e.db.WithContext(ctx).Debug().
+// FindDistinctCurrencies return the currencies of the salaries, the deleted employees included
+func (e *employeeRepository) FindDistinctCurrencies(ctx context.Context) ([]string, error) {
+	var currencies []string
+	err := e.db.WithContext(ctx).
+		Unscoped().
 		Model(&model.Employee{}).
-		Select("DISTINCT position").
-		Pluck("position", &positions).Error
+		Distinct("currency").
+		Order("currency").
+		Pluck("currency", &currencies).Error
+	if err != nil {
+		logrus.WithField("ctx", utils.DumpIncomingContext(ctx)).Error(err)
+		return nil, err
+	}
 
+	return currencies, nil
+}
+
+// FindSuggestions find the most common values of the field matching the prefix, the result is cached
+// for config.SuggestCacheTTL and dropped on any change of the employees by bumping the suggestion cache generation
+func (e *employeeRepository) FindSuggestions(ctx context.Context, criteria model.EmployeeSuggestCriteria) ([]*model.EmployeeSuggestion, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      utils.DumpIncomingContext(ctx),
+		"criteria": utils.Dump(criteria),
+	})
+
+	generation, err := findCacheGeneration(e.cacheManager, suggestCacheGenerationKey)
 	if err != nil {
-		logrus.WithContext(ctx).Error(err)
+		logger.Error(err)
 		return nil, err
 	}
 
-	return positions, nil
+	prefix := strings.ToLower(criteria.Prefix)
+	cacheKey := e.newSuggestCacheKey(generation, criteria.Field, criteria.Limit, prefix)
+	reply, err := e.cacheManager.GetOrSet(cacheKey, func() (any, error) {
+		column := clause.Column{Name: string(criteria.Field)}
+		pattern := escapeLike(prefix) + "%"
+
+		suggestions := []*model.EmployeeSuggestion{}
+		err := e.db.WithContext(ctx).
+			Model(&model.Employee{}).
+			Select("? AS value, COUNT(*) AS count", column).
+			Where("? ILIKE ? OR ? ILIKE ?", column, pattern, column, "% "+pattern).
+			Group("value").
+			Order("count DESC, value").
+			Limit(criteria.Limit).
+			Find(&suggestions).Error
+		return suggestions, err
+	}, cacher.WithTTL(config.SuggestCacheTTL()))
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	suggestions := []*model.EmployeeSuggestion{}
+	if reply == nil {
+		return suggestions, nil
+	}
+
+	if err := json.Unmarshal(reply, &suggestions); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return suggestions, nil
 }
 
 func (e *employeeRepository) countAll(ctx context.Context, criteria model.EmployeeSearchCriteria) (int64, error) {
+	var count int64
+	err := e.db.WithContext(ctx).Model(model.Employee{}).
+		Scopes(scopesByCriteria(criteria)...).
+		Count(&count).
+		Error
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":      utils.DumpIncomingContext(ctx),
+			"criteria": utils.DumpRedacted(criteria),
+		}).Error(err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// scopesByCriteria return the filters of the criteria, shared by the count, the search and the export
+func scopesByCriteria(criteria model.EmployeeSearchCriteria) []func(*gorm.DB) *gorm.DB {
 	var scopes []func(*gorm.DB) *gorm.DB
 
 	// Add LIKE query for name if provided
@@ -230,20 +614,307 @@ func (e *employeeRepository) countAll(ctx context.Context, criteria model.Employ
 		})
 	}
 
-	var count int64
-	err := e.db.WithContext(ctx).Model(model.Employee{}).
-		Scopes(scopes...).
-		Count(&count).
-		Error
+	if criteria.DepartmentID > 0 {
+		scopes = append(scopes, func(db *gorm.DB) *gorm.DB {
+			return db.Where("department_id = ?", criteria.DepartmentID)
+		})
+	}
+
+	if criteria.Query != "" {
+		scopes = append(scopes, func(db *gorm.DB) *gorm.DB {
+			return db.Clauses(clause.Where{Exprs: []clause.Expression{queryMatchExpr(criteria.Query)}})
+		})
+	}
+
+	if exprs := filterExprs(&criteria.Filter, salaryColumn(&criteria)); len(exprs) > 0 {
+		scopes = append(scopes, func(db *gorm.DB) *gorm.DB {
+			return db.Clauses(clause.Where{Exprs: exprs})
+		})
+	}
+
+	if criteria.Filter.IncludeDeleted {
+		scopes = append(scopes, func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped()
+		})
+	}
+
+	return scopes
+}
+
+// queryMatchExpr match the names similar to q, see the pg_trgm word_similarity, or the name and position
+// words starting with a word of q. Both conditions use the indexes of the search migration.
+func queryMatchExpr(q string) clause.Expression {
+	trigram := clause.Expr{SQL: "? <% name", Vars: []any{q}}
+
+	tsQuery := prefixTSQuery(q)
+	if tsQuery == "" {
+		return trigram
+	}
+
+	return clause.Or(
+		clause.Expr{SQL: "search_vector @@ to_tsquery('simple', ?)", Vars: []any{tsQuery}},
+		trigram,
+	)
+}
+
+// queryScoreExpr the relevance of an employee to q between 0 and 1, the best of the name similarity
+// and the full text rank
+func queryScoreExpr(q string) clause.Expression {
+	tsQuery := prefixTSQuery(q)
+	if tsQuery == "" {
+		return clause.Expr{SQL: "GREATEST(word_similarity(?, name), similarity(name, ?))", Vars: []any{q, q}}
+	}
+
+	return clause.Expr{
+		SQL:  "GREATEST(word_similarity(?, name), similarity(name, ?), ts_rank(search_vector, to_tsquery('simple', ?), 1))",
+		Vars: []any{q, q, tsQuery},
+	}
+}
+
+// prefixTSQuery build a tsquery matching any word of q by prefix, e.g. "budi sas" becomes "budi:* | sas:*".
+// Only the letters and digits are kept, so the user input can never break the tsquery syntax.
+func prefixTSQuery(q string) string {
+	words := utils.SearchTerms(q)
+	for idx, word := range words {
+		words[idx] = word + ":*"
+	}
+
+	return strings.Join(words, " | ")
+}
+
+// filterExprs compile the filter into where expressions, the column names are fixed and every value is bound.
+// The salary conditions apply to the salary column, see salaryColumn.
+func filterExprs(filter *model.EmployeeFilter, salary any) []clause.Expression {
+	if filter == nil {
+		return nil
+	}
+
+	var exprs []clause.Expression
+	exprs = append(exprs, stringFilterExprs("name", filter.Name)...)
+	exprs = append(exprs, stringFilterExprs("position", filter.Position)...)
+	exprs = append(exprs, rangeFilterExprs(salary, filter.Salary)...)
+	exprs = append(exprs, rangeFilterExprs(clause.Column{Name: "created_at"}, filter.CreatedAt)...)
+	exprs = append(exprs, rangeFilterExprs(clause.Column{Name: "updated_at"}, filter.UpdatedAt)...)
+	exprs = append(exprs, idFilterExprs("department_id", filter.DepartmentID)...)
+
+	// clause.Not negates each expression, the group as a whole is negated instead
+	if notExprs := filterExprs(filter.Not, salary); len(notExprs) > 0 {
+		exprs = append(exprs, clause.Expr{SQL: "NOT (?)", Vars: []any{clause.And(notExprs...)}})
+	}
+
+	return exprs
+}
+
+func stringFilterExprs(name string, filter *model.StringFilter) []clause.Expression {
+	if filter == nil {
+		return nil
+	}
+
+	column := clause.Column{Name: name}
+	var exprs []clause.Expression
+	if filter.Eq != nil {
+		exprs = append(exprs, clause.Eq{Column: column, Value: *filter.Eq})
+	}
+	if filter.Ne != nil {
+		exprs = append(exprs, clause.Neq{Column: column, Value: *filter.Ne})
+	}
+	if filter.Like != nil {
+		exprs = append(exprs, clause.Expr{SQL: "? ILIKE ?", Vars: []any{column, "%" + escapeLike(*filter.Like) + "%"}})
+	}
+	if len(filter.In) > 0 {
+		exprs = append(exprs, clause.IN{Column: column, Values: toAnySlice(filter.In)})
+	}
+	if len(filter.Nin) > 0 {
+		exprs = append(exprs, clause.Not(clause.IN{Column: column, Values: toAnySlice(filter.Nin)}))
+	}
+
+	return exprs
+}
+
+// idFilterExprs compile the conditions on a nullable reference column,
+// ne and nin also match the rows without a reference
+func idFilterExprs(name string, filter *model.IDFilter) []clause.Expression {
+	if filter == nil {
+		return nil
+	}
+
+	column := clause.Column{Name: name}
+	var exprs []clause.Expression
+	if filter.Eq != nil {
+		exprs = append(exprs, clause.Eq{Column: column, Value: *filter.Eq})
+	}
+	if filter.Ne != nil {
+		exprs = append(exprs, clause.Expr{SQL: "? IS DISTINCT FROM ?", Vars: []any{column, *filter.Ne}})
+	}
+	if len(filter.In) > 0 {
+		exprs = append(exprs, clause.IN{Column: column, Values: toAnySlice(filter.In)})
+	}
+	if len(filter.Nin) > 0 {
+		exprs = append(exprs, clause.Or(
+			clause.Eq{Column: column, Value: nil},
+			clause.Not(clause.IN{Column: column, Values: toAnySlice(filter.Nin)}),
+		))
+	}
+	if filter.Null != nil {
+		if *filter.Null {
+			exprs = append(exprs, clause.Eq{Column: column, Value: nil})
+		} else {
+			exprs = append(exprs, clause.Neq{Column: column, Value: nil})
+		}
+	}
+
+	return exprs
+}
+
+// rangeFilterExprs compile the conditions on the column, a clause.Column or an expression such as the
+// normalized salary
+func rangeFilterExprs[T model.Money | time.Time](column any, filter *model.RangeFilter[T]) []clause.Expression {
+	if filter == nil {
+		return nil
+	}
+
+	var exprs []clause.Expression
+	if filter.Eq != nil {
+		exprs = append(exprs, clause.Eq{Column: column, Value: *filter.Eq})
+	}
+	if filter.Ne != nil {
+		exprs = append(exprs, clause.Neq{Column: column, Value: *filter.Ne})
+	}
+	if filter.Gt != nil {
+		exprs = append(exprs, clause.Gt{Column: column, Value: *filter.Gt})
+	}
+	if filter.Gte != nil {
+		exprs = append(exprs, clause.Gte{Column: column, Value: *filter.Gte})
+	}
+	if filter.Lt != nil {
+		exprs = append(exprs, clause.Lt{Column: column, Value: *filter.Lt})
+	}
+	if filter.Lte != nil {
+		exprs = append(exprs, clause.Lte{Column: column, Value: *filter.Lte})
+	}
+
+	return exprs
+}
+
+// orderBySort build the ORDER BY of a validated sort, the columns are quoted and never taken from the input as is.
+// The salary is sorted on the salary column, see salaryColumn.
+func orderBySort(sort []model.EmployeeSortField, salary any) clause.OrderBy {
+	exprs := make([]clause.Expression, 0, len(sort))
+	for _, field := range sort {
+		sql := "?"
+		if field.Desc {
+			sql = "? DESC"
+		}
+		exprs = append(exprs, clause.Expr{SQL: sql, Vars: []any{sortColumn(field.Column, salary)}})
+	}
+
+	return clause.OrderBy{Expression: clause.CommaExpression{Exprs: exprs}}
+}
+
+// scopeAfterCursor seek past the sort key of the cursor, for a sort on a, b, id it is
+// (a > va) OR (a = va AND b > vb) OR (a = va AND b = vb AND id > vid), with < for the descending fields
+func scopeAfterCursor(cursor *model.EmployeeCursor, salary any) func(*gorm.DB) *gorm.DB {
+	var after []clause.Expression
+	for idx, field := range cursor.Sort {
+		var exprs []clause.Expression
+		for prev := 0; prev < idx; prev++ {
+			exprs = append(exprs, clause.Eq{Column: sortColumn(cursor.Sort[prev].Column, salary), Value: cursor.Values[prev]})
+		}
+
+		column := sortColumn(field.Column, salary)
+		if field.Desc {
+			exprs = append(exprs, clause.Lt{Column: column, Value: cursor.Values[idx]})
+		} else {
+			exprs = append(exprs, clause.Gt{Column: column, Value: cursor.Values[idx]})
+		}
+
+		after = append(after, clause.And(exprs...))
+	}
+
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Clauses(clause.Where{Exprs: []clause.Expression{clause.Or(after...)}})
+	}
+}
+
+func sortColumn(name string, salary any) any {
+	if name == "salary" {
+		return salary
+	}
+
+	return clause.Column{Name: name}
+}
+
+// salaryColumn the salary the criteria filters and sorts on, the salary normalized into the reporting currency
+// when the criteria has rates, the stored salary otherwise
+func salaryColumn(criteria *model.EmployeeSearchCriteria) any {
+	if criteria.Rates == nil {
+		return clause.Column{Name: "salary"}
+	}
+
+	return reportingSalaryExpr(criteria.Rates, criteria.ReportingCurrency)
+}
+
+// reportingSalaryExpr the salary converted into the currency through the base currency, rounded to the nearest
+// minor unit in numeric. The rates are bound, a currency without a rate converts to NULL.
+func reportingSalaryExpr(rates *model.ExchangeRates, currency string) clause.Expr {
+	currencies := make([]string, 0, len(rates.Rates))
+	for code := range rates.Rates {
+		currencies = append(currencies, code)
+	}
+	// the same rates always give the same statement
+	sort.Strings(currencies)
+
+	toRate, _ := rates.Rate(currency)
+	var sql strings.Builder
+	vars := make([]any, 0, 2*len(currencies)+1)
+	sql.WriteString("ROUND(salary * CASE currency")
+	for _, code := range currencies {
+		sql.WriteString(" WHEN ? THEN ?::numeric")
+		vars = append(vars, code, rates.Rates[code])
+	}
+	sql.WriteString(" END / ?::numeric)::BIGINT")
+	vars = append(vars, toRate)
+
+	return clause.Expr{SQL: sql.String(), Vars: vars}
+}
+
+// findByIDForUpdate find the employee and lock the row until the transaction ends
+func findByIDForUpdate(tx *gorm.DB, id int64) (*model.Employee, error) {
+	employee := &model.Employee{}
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(employee, "id = ?", id).Error
 	if err != nil {
-		logrus.WithFields(logrus.Fields{
-			"ctx":      utils.DumpIncomingContext(ctx),
-			"criteria": utils.Dump(criteria),
-		}).Error(err)
-		return 0, err
+		return nil, err
 	}
 
-	return count, nil
+	return employee, nil
+}
+
+// lockDepartment share lock the department an employee is assigned to until the transaction ends,
+// so it can't be deleted in between. model.ErrDepartmentNotFound is returned when it doesn't exist.
+func lockDepartment(tx *gorm.DB, departmentID *int64) error {
+	if departmentID == nil {
+		return nil
+	}
+
+	err := tx.Clauses(clause.Locking{Strength: "SHARE"}).Take(&model.Department{}, "id = ?", *departmentID).Error
+	switch err {
+	case nil:
+		return nil
+	case gorm.ErrRecordNotFound:
+		return model.ErrDepartmentNotFound
+	default:
+		return err
+	}
+}
+
+// deleteSuggestCaches drop every cached suggestion by bumping their generation, the count of any of them may
+// have changed
+func (e *employeeRepository) deleteSuggestCaches() {
+	bumpCacheGeneration(e.cacheManager, suggestCacheGenerationKey)
+}
+
+func (e *employeeRepository) newSuggestCacheKey(generation int64, field model.EmployeeSuggestField, limit int, prefix string) string {
+	return fmt.Sprintf("cache:suggest:employee:%d:%s:%d:%s", generation, field, limit, prefix)
 }
 
 func (e *employeeRepository) newCacheKeyByID(id int64) string {
@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"context"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/irvankadhafi/employee-api/utils"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"time"
+)
+
+const (
+	// salaryChangeReasonHired the reason recorded for the salary an employee is created with
+	salaryChangeReasonHired = "hired"
+	// salaryChangeReasonUpdated the reason recorded when the salary is overwritten by an employee update
+	salaryChangeReasonUpdated = "employee updated"
+)
+
+func (e *employeeRepository) FindSalaryChangesByCriteria(ctx context.Context, criteria model.SalaryChangeCriteria) (changes []*model.SalaryChange, count int64, err error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      utils.DumpIncomingContext(ctx),
+		"criteria": utils.Dump(criteria),
+	})
+
+	err = e.db.WithContext(ctx).Model(&model.SalaryChange{}).
+		Where("employee_id = ?", criteria.EmployeeID).
+		Count(&count).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	if count <= 0 {
+		return nil, 0, nil
+	}
+
+	err = e.db.WithContext(ctx).
+		Where("employee_id = ?", criteria.EmployeeID).
+		Scopes(scopeByPageAndLimit(criteria.Page, criteria.Size)).
+		Order("effective_from DESC, id DESC").
+		Find(&changes).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	return changes, count, nil
+}
+
+// FindSalariesOn return the salary of each employee on the day, its latest change effective on that day with only
+// the amount and the currency. The employees without any change effective yet are left out.
+func (e *employeeRepository) FindSalariesOn(ctx context.Context, employeeIDs []int64, day time.Time) (map[int64]*model.SalaryChange, error) {
+	if len(employeeIDs) == 0 {
+		return nil, nil
+	}
+
+	var changes []*model.SalaryChange
+	err := e.db.WithContext(ctx).
+		Select("DISTINCT ON (employee_id) employee_id, amount, currency").
+		Where("employee_id IN ? AND effective_from <= ?", employeeIDs, day).
+		Order("employee_id, effective_from DESC, id DESC").
+		Find(&changes).Error
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":         utils.DumpIncomingContext(ctx),
+			"employeeIDs": employeeIDs,
+			"day":         day,
+		}).Error(err)
+		return nil, err
+	}
+
+	salaries := make(map[int64]*model.SalaryChange, len(changes))
+	for _, change := range changes {
+		salaries[change.EmployeeID] = change
+	}
+
+	return salaries, nil
+}
+
+// CreateSalaryChange record the salary change, approved by the user of the context. A change already effective
+// is applied to the employee right away, unless a later change is effective too.
+func (e *employeeRepository) CreateSalaryChange(ctx context.Context, change *model.SalaryChange) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.DumpIncomingContext(ctx),
+		"change": utils.DumpRedacted(change),
+	})
+
+	change.ApprovedBy = actorFromCtx(ctx)
+	var applied bool
+	err := e.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		employee, err := findByIDForUpdate(tx, change.EmployeeID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Create(change).Error; err != nil {
+			return err
+		}
+
+		applied, err = applyDueSalaryChanges(ctx, tx, employee, model.Today())
+		return err
+	})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if applied {
+		if err := e.cacheManager.DeleteByKeys([]string{e.newCacheKeyByID(change.EmployeeID)}); err != nil {
+			logger.Error(err)
+		}
+	}
+
+	return nil
+}
+
+// FindEmployeeIDsWithDueSalaryChanges find the employees having a change effective on the day which is not
+// applied yet, the deleted employees are left out
+func (e *employeeRepository) FindEmployeeIDsWithDueSalaryChanges(ctx context.Context, day time.Time) (ids []int64, err error) {
+	err = e.db.WithContext(ctx).Model(&model.SalaryChange{}).
+		Distinct("salary_changes.employee_id").
+		Joins("JOIN employees ON employees.id = salary_changes.employee_id AND employees.deleted_at IS NULL").
+		Where("salary_changes.applied_at IS NULL AND salary_changes.effective_from <= ?", day).
+		Order("salary_changes.employee_id").
+		Pluck("salary_changes.employee_id", &ids).Error
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.DumpIncomingContext(ctx),
+			"day": day,
+		}).Error(err)
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// ApplyDueSalaryChanges mark the changes of the employee effective on the day as applied and set its salary
+// to the amount and the currency of the latest of them, applied tells whether the salary changed
+func (e *employeeRepository) ApplyDueSalaryChanges(ctx context.Context, employeeID int64, day time.Time) (applied bool, err error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":        utils.DumpIncomingContext(ctx),
+		"employeeID": employeeID,
+		"day":        day,
+	})
+
+	err = e.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		employee, err := findByIDForUpdate(tx, employeeID)
+		if err != nil {
+			return err
+		}
+
+		applied, err = applyDueSalaryChanges(ctx, tx, employee, day)
+		return err
+	})
+	if err != nil {
+		logger.Error(err)
+		return false, err
+	}
+
+	if applied {
+		if err := e.cacheManager.DeleteByKeys([]string{e.newCacheKeyByID(employeeID)}); err != nil {
+			logger.Error(err)
+		}
+	}
+
+	return applied, nil
+}
+
+// applyDueSalaryChanges set the salary of the locked employee to its latest change effective on the day,
+// the version is bumped and the update audited when the salary changes
+func applyDueSalaryChanges(ctx context.Context, tx *gorm.DB, employee *model.Employee, day time.Time) (bool, error) {
+	err := tx.Model(&model.SalaryChange{}).
+		Where("employee_id = ? AND applied_at IS NULL AND effective_from <= ?", employee.ID, day).
+		Update("applied_at", time.Now()).Error
+	if err != nil {
+		return false, err
+	}
+
+	latest := &model.SalaryChange{}
+	err = tx.Where("employee_id = ? AND effective_from <= ?", employee.ID, day).
+		Order("effective_from DESC, id DESC").
+		Take(latest).Error
+	switch err {
+	case nil:
+	case gorm.ErrRecordNotFound:
+		return false, nil
+	default:
+		return false, err
+	}
+
+	if latest.Amount == employee.Salary && latest.Currency == employee.Currency {
+		return false, nil
+	}
+
+	after := *employee
+	after.Salary = latest.Amount
+	after.Currency = latest.Currency
+	after.Version++
+	err = tx.Model(&model.Employee{}).
+		Where("id = ?", employee.ID).
+		Updates(map[string]any{
+			"salary":   after.Salary,
+			"currency": after.Currency,
+			"version":  after.Version,
+		}).Error
+	if err != nil {
+		return false, err
+	}
+
+	return true, createAuditLog(ctx, tx, model.EmployeeAuditActionUpdate, employee.ID, employee, &after)
+}
+
+// recordSalaryChange write the salary the employee is created or updated with in its history, effective today
+func recordSalaryChange(ctx context.Context, tx *gorm.DB, employee *model.Employee, reason string) error {
+	now := time.Now()
+	return tx.Create(&model.SalaryChange{
+		EmployeeID:    employee.ID,
+		Amount:        employee.Salary,
+		Currency:      employee.Currency,
+		EffectiveFrom: model.Today(),
+		Reason:        reason,
+		ApprovedBy:    actorFromCtx(ctx),
+		AppliedAt:     &now,
+	}).Error
+}
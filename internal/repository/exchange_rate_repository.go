@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/irvankadhafi/employee-api/utils"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type exchangeRateRepository struct {
+	db *gorm.DB
+}
+
+// NewExchangeRateRepository the rates are read once per search or payroll, they are not cached
+func NewExchangeRateRepository(db *gorm.DB) model.ExchangeRateRepository {
+	return &exchangeRateRepository{db: db}
+}
+
+// Upsert create the rates in one transaction, a rate replaces the rate of its currency effective on the same day.
+// The rates can't repeat a currency and a day.
+func (e *exchangeRateRepository) Upsert(ctx context.Context, rates []*model.ExchangeRate) error {
+	if len(rates) == 0 {
+		return nil
+	}
+
+	actor := actorFromCtx(ctx)
+	for _, rate := range rates {
+		rate.CreatedBy = actor
+	}
+
+	err := e.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "currency"}, {Name: "effective_from"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "created_by", "updated_at"}),
+	}).CreateInBatches(&rates, 500).Error
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":   utils.DumpIncomingContext(ctx),
+			"rates": utils.Dump(rates),
+		}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (e *exchangeRateRepository) FindByID(ctx context.Context, id int64) (*model.ExchangeRate, error) {
+	rate := &model.ExchangeRate{}
+	err := e.db.WithContext(ctx).Take(rate, "id = ?", id).Error
+	switch err {
+	case nil:
+		return rate, nil
+	case gorm.ErrRecordNotFound:
+		return nil, nil
+	default:
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.DumpIncomingContext(ctx),
+			"id":  id,
+		}).Error(err)
+		return nil, err
+	}
+}
+
+// FindAllByCriteria list the rates, by currency then the latest effective first
+func (e *exchangeRateRepository) FindAllByCriteria(ctx context.Context, criteria model.ExchangeRateCriteria) (rates []*model.ExchangeRate, count int64, err error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      utils.DumpIncomingContext(ctx),
+		"criteria": utils.Dump(criteria),
+	})
+
+	var scopes []func(*gorm.DB) *gorm.DB
+	if criteria.Currency != "" {
+		scopes = append(scopes, func(db *gorm.DB) *gorm.DB {
+			return db.Where("currency = ?", criteria.Currency)
+		})
+	}
+
+	err = e.db.WithContext(ctx).Model(&model.ExchangeRate{}).Scopes(scopes...).Count(&count).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	if count <= 0 {
+		return nil, 0, nil
+	}
+
+	err = e.db.WithContext(ctx).
+		Scopes(scopes...).
+		Scopes(scopeByPageAndLimit(criteria.Page, criteria.Size)).
+		Order("currency ASC, effective_from DESC").
+		Find(&rates).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	return rates, count, nil
+}
+
+// FindRatesOn return the rate of each currency effective on the day, the latest rate effective on that day.
+// The currencies without any rate effective yet are left out.
+func (e *exchangeRateRepository) FindRatesOn(ctx context.Context, currencies []string, day time.Time) (*model.ExchangeRates, error) {
+	rates := model.NewExchangeRates(day)
+	if len(currencies) == 0 {
+		return rates, nil
+	}
+
+	var effective []*model.ExchangeRate
+	err := e.db.WithContext(ctx).
+		Select("DISTINCT ON (currency) currency, rate").
+		Where("currency IN ? AND effective_from <= ?", currencies, day).
+		Order("currency, effective_from DESC").
+		Find(&effective).Error
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":        utils.DumpIncomingContext(ctx),
+			"currencies": currencies,
+			"day":        day,
+		}).Error(err)
+		return nil, err
+	}
+
+	for _, rate := range effective {
+		rates.Rates[rate.Currency] = rate.Rate
+	}
+
+	return rates, nil
+}
+
+func (e *exchangeRateRepository) Delete(ctx context.Context, id int64) error {
+	err := e.db.WithContext(ctx).Delete(&model.ExchangeRate{}, "id = ?", id).Error
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.DumpIncomingContext(ctx),
+			"id":  id,
+		}).Error(err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/irvankadhafi/employee-api/cacher"
+	"github.com/irvankadhafi/employee-api/internal/config"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/irvankadhafi/employee-api/utils"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type payrollRepository struct {
+	db           *gorm.DB
+	cacheManager cacher.CacheManager
+}
+
+func NewPayrollRepository(db *gorm.DB, cacheManager cacher.CacheManager) model.PayrollRepository {
+	return &payrollRepository{
+		db:           db,
+		cacheManager: cacheManager,
+	}
+}
+
+// FindProfileByEmployeeID find the payroll profile of the employee, nil when it has none
+func (p *payrollRepository) FindProfileByEmployeeID(ctx context.Context, employeeID int64) (*model.PayrollProfile, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":        utils.DumpIncomingContext(ctx),
+		"employeeID": employeeID,
+	})
+
+	cacheKey := p.newProfileCacheKeyByEmployeeID(employeeID)
+	if !config.DisableCaching() {
+		reply, mu, err := findFromCacheByKey[*model.PayrollProfile](p.cacheManager, cacheKey)
+		defer cacher.SafeUnlock(mu)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		if mu == nil {
+			return reply, nil
+		}
+	}
+
+	profile := &model.PayrollProfile{}
+	err := p.db.WithContext(ctx).Take(profile, "employee_id = ?", employeeID).Error
+	switch err {
+	case nil:
+	case gorm.ErrRecordNotFound:
+		storeNil(p.cacheManager, cacheKey)
+		return nil, nil
+	default:
+		logger.Error(err)
+		return nil, err
+	}
+
+	err = p.cacheManager.StoreWithoutBlocking(cacher.NewItem(cacheKey, utils.Dump(profile)))
+	if err != nil {
+		logger.Error(err)
+	}
+
+	return profile, nil
+}
+
+// FindProfilesByEmployeeIDs find the payroll profiles of the employees, those without one are left out
+func (p *payrollRepository) FindProfilesByEmployeeIDs(ctx context.Context, employeeIDs []int64) (profiles []*model.PayrollProfile, err error) {
+	if len(employeeIDs) == 0 {
+		return nil, nil
+	}
+
+	err = p.db.WithContext(ctx).Where("employee_id IN ?", employeeIDs).Find(&profiles).Error
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":         utils.DumpIncomingContext(ctx),
+			"employeeIDs": employeeIDs,
+		}).Error(err)
+		return nil, err
+	}
+
+	return profiles, nil
+}
+
+// UpsertProfile create or overwrite the payroll profile of the employee
+func (p *payrollRepository) UpsertProfile(ctx context.Context, profile *model.PayrollProfile) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":     utils.DumpIncomingContext(ctx),
+		"profile": utils.DumpRedacted(profile),
+	})
+
+	err := p.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "employee_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"ptkp_status", "allowances", "deductions", "updated_at"}),
+	}).Create(profile).Error
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if err := p.cacheManager.DeleteByKeys([]string{p.newProfileCacheKeyByEmployeeID(profile.EmployeeID)}); err != nil {
+		logger.Error(err)
+	}
+
+	return nil
+}
+
+func (p *payrollRepository) newProfileCacheKeyByEmployeeID(employeeID int64) string {
+	return fmt.Sprintf("cache:object:payroll_profile:employee_id:%d", employeeID)
+}
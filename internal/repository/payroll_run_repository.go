@@ -0,0 +1,366 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-redsync/redsync/v4"
+	"github.com/irvankadhafi/employee-api/cacher"
+	"github.com/irvankadhafi/employee-api/internal/config"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/irvankadhafi/employee-api/utils"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// uniqueViolationCode the SQLSTATE of a unique index violation
+const uniqueViolationCode = "23505"
+
+// payrollRunItemBatchSize the number of items inserted per statement
+const payrollRunItemBatchSize = 500
+
+var paidOutPayrollRunStatuses = []model.PayrollRunStatus{model.PayrollRunStatusApproved, model.PayrollRunStatusPaid}
+
+// FindRunByPeriod find the run of the period, whatever its status, nil when there is none
+func (p *payrollRepository) FindRunByPeriod(ctx context.Context, period string) (*model.PayrollRun, error) {
+	run, err := p.findRunByPeriod(p.db.WithContext(ctx), period)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":    utils.DumpIncomingContext(ctx),
+			"period": period,
+		}).Error(err)
+		return nil, err
+	}
+
+	return run, nil
+}
+
+// CreateRun insert the run and its items in a transaction, while holding the lock of the period.
+// model.ErrPayrollRunInProgress is returned when another run of the period is in progress or being created,
+// model.ErrPayrollRunApproved when the run of the period is already approved or paid.
+func (p *payrollRepository) CreateRun(ctx context.Context, run *model.PayrollRun, items []*model.PayrollRunItem) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.DumpIncomingContext(ctx),
+		"period": run.Period,
+	})
+
+	// without redis the unique index on the period of the runs is the only guard
+	if !config.DisableCaching() {
+		mu, err := p.cacheManager.AcquireLock(p.newRunLockKeyByPeriod(run.Period))
+		defer cacher.SafeUnlock(mu)
+		if err != nil {
+			var taken *redsync.ErrTaken
+			if errors.Is(err, redsync.ErrFailed) || errors.As(err, &taken) {
+				return model.ErrPayrollRunInProgress
+			}
+
+			logger.Error(err)
+			return err
+		}
+	}
+
+	run.CreatedBy = actorFromCtx(ctx)
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		existing, err := p.findRunByPeriod(tx, run.Period)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case existing == nil:
+			break
+		case existing.Status.IsInProgress():
+			return model.ErrPayrollRunInProgress
+		default:
+			return model.ErrPayrollRunApproved
+		}
+
+		if err := tx.Create(run).Error; err != nil {
+			return err
+		}
+
+		if len(items) == 0 {
+			return nil
+		}
+
+		for _, item := range items {
+			item.PayrollRunID = run.ID
+		}
+
+		return tx.CreateInBatches(items, payrollRunItemBatchSize).Error
+	})
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, model.ErrPayrollRunInProgress), isUniqueViolation(err):
+		return model.ErrPayrollRunInProgress
+	case errors.Is(err, model.ErrPayrollRunApproved):
+		return model.ErrPayrollRunApproved
+	default:
+		logger.Error(err)
+		return err
+	}
+}
+
+// FindRunByID find the run by its id, nil when it doesn't exist
+func (p *payrollRepository) FindRunByID(ctx context.Context, id int64) (*model.PayrollRun, error) {
+	run := &model.PayrollRun{}
+	err := p.db.WithContext(ctx).Take(run, "id = ?", id).Error
+	switch err {
+	case nil:
+		return run, nil
+	case gorm.ErrRecordNotFound:
+		return nil, nil
+	default:
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.DumpIncomingContext(ctx),
+			"id":  id,
+		}).Error(err)
+		return nil, err
+	}
+}
+
+// FindRunsByCriteria find the runs matching the period and the status, the latest first
+func (p *payrollRepository) FindRunsByCriteria(ctx context.Context, criteria model.PayrollRunCriteria) (runs []*model.PayrollRun, count int64, err error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      utils.DumpIncomingContext(ctx),
+		"criteria": utils.Dump(criteria),
+	})
+
+	var scopes []func(*gorm.DB) *gorm.DB
+	if criteria.Period != "" {
+		scopes = append(scopes, func(db *gorm.DB) *gorm.DB {
+			return db.Where("period = ?", criteria.Period)
+		})
+	}
+	if criteria.Status != "" {
+		scopes = append(scopes, func(db *gorm.DB) *gorm.DB {
+			return db.Where("status = ?", criteria.Status)
+		})
+	}
+
+	err = p.db.WithContext(ctx).Model(&model.PayrollRun{}).Scopes(scopes...).Count(&count).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	if count <= 0 {
+		return nil, 0, nil
+	}
+
+	err = p.db.WithContext(ctx).
+		Scopes(scopes...).
+		Scopes(scopeByPageAndLimit(criteria.Page, criteria.Size)).
+		Order("period DESC, id DESC").
+		Find(&runs).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	return runs, count, nil
+}
+
+// FindRunItemsByCriteria find the items of the run, ordered by employee
+func (p *payrollRepository) FindRunItemsByCriteria(ctx context.Context, criteria model.PayrollRunItemCriteria) (items []*model.PayrollRunItem, count int64, err error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      utils.DumpIncomingContext(ctx),
+		"criteria": utils.Dump(criteria),
+	})
+
+	scope := func(db *gorm.DB) *gorm.DB {
+		return db.Where("payroll_run_id = ?", criteria.PayrollRunID)
+	}
+
+	err = p.db.WithContext(ctx).Model(&model.PayrollRunItem{}).Scopes(scope).Count(&count).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	if count <= 0 {
+		return nil, 0, nil
+	}
+
+	err = p.db.WithContext(ctx).
+		Scopes(scope).
+		Scopes(scopeByPageAndLimit(criteria.Page, criteria.Size)).
+		Order("employee_id ASC").
+		Find(&items).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	return items, count, nil
+}
+
+// FindPaidOutItem find the item of the employee in the approved or paid run of the period, nil when there is none
+func (p *payrollRepository) FindPaidOutItem(ctx context.Context, employeeID int64, period string) (*model.PayrollRunItem, error) {
+	item := &model.PayrollRunItem{}
+	err := p.db.WithContext(ctx).
+		Select("payroll_run_items.*").
+		Joins("JOIN payroll_runs ON payroll_runs.id = payroll_run_items.payroll_run_id").
+		Where("payroll_run_items.employee_id = ?", employeeID).
+		Where("payroll_runs.period = ? AND payroll_runs.status IN ?", period, paidOutPayrollRunStatuses).
+		Take(item).Error
+	switch err {
+	case nil:
+		return item, nil
+	case gorm.ErrRecordNotFound:
+		return nil, nil
+	default:
+		logrus.WithFields(logrus.Fields{
+			"ctx":        utils.DumpIncomingContext(ctx),
+			"employeeID": employeeID,
+			"period":     period,
+		}).Error(err)
+		return nil, err
+	}
+}
+
+// FindRunItemsByEmployeeIDs find the items of the employees in the run, an employee left out of the run has none
+func (p *payrollRepository) FindRunItemsByEmployeeIDs(ctx context.Context, runID int64, employeeIDs []int64) (items []*model.PayrollRunItem, err error) {
+	if len(employeeIDs) == 0 {
+		return nil, nil
+	}
+
+	err = p.db.WithContext(ctx).
+		Where("payroll_run_id = ? AND employee_id IN ?", runID, employeeIDs).
+		Find(&items).Error
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":         utils.DumpIncomingContext(ctx),
+			"runID":       runID,
+			"employeeIDs": employeeIDs,
+		}).Error(err)
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// FindPaidOutRunByPeriod find the approved or paid run of the period, nil when there is none
+func (p *payrollRepository) FindPaidOutRunByPeriod(ctx context.Context, period string) (*model.PayrollRun, error) {
+	run := &model.PayrollRun{}
+	err := p.db.WithContext(ctx).
+		Where("period = ? AND status IN ?", period, paidOutPayrollRunStatuses).
+		Take(run).Error
+	switch err {
+	case nil:
+		return run, nil
+	case gorm.ErrRecordNotFound:
+		return nil, nil
+	default:
+		logrus.WithFields(logrus.Fields{
+			"ctx":    utils.DumpIncomingContext(ctx),
+			"period": period,
+		}).Error(err)
+		return nil, err
+	}
+}
+
+// UpdateRunStatus move the run from the status to its status, recording who moved it and when.
+// model.ErrPayrollRunStatusChanged is returned when the run is no longer in the status.
+func (p *payrollRepository) UpdateRunStatus(ctx context.Context, run *model.PayrollRun, from model.PayrollRunStatus) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":  utils.DumpIncomingContext(ctx),
+		"id":   run.ID,
+		"from": from,
+		"to":   run.Status,
+	})
+
+	actor := actorFromCtx(ctx)
+	now := time.Now()
+	updates := map[string]any{"status": run.Status, "updated_at": now}
+	switch run.Status {
+	case model.PayrollRunStatusReviewed:
+		run.ReviewedBy, run.ReviewedAt = &actor, &now
+		updates["reviewed_by"], updates["reviewed_at"] = actor, now
+	case model.PayrollRunStatusApproved:
+		run.ApprovedBy, run.ApprovedAt = &actor, &now
+		updates["approved_by"], updates["approved_at"] = actor, now
+	case model.PayrollRunStatusPaid:
+		run.PaidBy, run.PaidAt = &actor, &now
+		updates["paid_by"], updates["paid_at"] = actor, now
+	}
+
+	res := p.db.WithContext(ctx).Model(&model.PayrollRun{}).
+		Where("id = ? AND status = ?", run.ID, from).
+		Updates(updates)
+	if res.Error != nil {
+		logger.Error(res.Error)
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return model.ErrPayrollRunStatusChanged
+	}
+
+	run.UpdatedAt = &now
+	return nil
+}
+
+// DeleteRun delete the run and its items, model.ErrPayrollRunReadOnly is returned when the run is approved
+func (p *payrollRepository) DeleteRun(ctx context.Context, id int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.DumpIncomingContext(ctx),
+		"id":  id,
+	})
+
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		run := &model.PayrollRun{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(run, "id = ?", id).Error
+		switch err {
+		case nil:
+			break
+		case gorm.ErrRecordNotFound:
+			return nil
+		default:
+			return err
+		}
+
+		if !run.Status.IsInProgress() {
+			return model.ErrPayrollRunReadOnly
+		}
+
+		if err := tx.Where("payroll_run_id = ?", id).Delete(&model.PayrollRunItem{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&model.PayrollRun{}, id).Error
+	})
+	switch err {
+	case nil, model.ErrPayrollRunReadOnly:
+		return err
+	default:
+		logger.Error(err)
+		return err
+	}
+}
+
+func (p *payrollRepository) findRunByPeriod(db *gorm.DB, period string) (*model.PayrollRun, error) {
+	run := &model.PayrollRun{}
+	err := db.Where("period = ?", period).Take(run).Error
+	switch err {
+	case nil:
+		return run, nil
+	case gorm.ErrRecordNotFound:
+		return nil, nil
+	default:
+		return nil, err
+	}
+}
+
+func (p *payrollRepository) newRunLockKeyByPeriod(period string) string {
+	return fmt.Sprintf("payroll_run:period:%s", period)
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
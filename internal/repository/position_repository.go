@@ -0,0 +1,289 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/irvankadhafi/employee-api/cacher"
+	"github.com/irvankadhafi/employee-api/internal/config"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/irvankadhafi/employee-api/utils"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+	"time"
+)
+
+type positionRepository struct {
+	db           *gorm.DB
+	cacheManager cacher.CacheManager
+}
+
+func NewPositionRepository(db *gorm.DB, cacheManager cacher.CacheManager) model.PositionRepository {
+	return &positionRepository{
+		db:           db,
+		cacheManager: cacheManager,
+	}
+}
+
+func (p *positionRepository) Create(ctx context.Context, position *model.Position) error {
+	err := p.db.WithContext(ctx).Create(position).Error
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":      utils.DumpIncomingContext(ctx),
+			"position": utils.Dump(position),
+		}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (p *positionRepository) FindByID(ctx context.Context, id int64) (*model.Position, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.DumpIncomingContext(ctx),
+		"id":  id,
+	})
+
+	cacheKey := p.newCacheKeyByID(id)
+	if !config.DisableCaching() {
+		reply, mu, err := findFromCacheByKey[*model.Position](p.cacheManager, cacheKey)
+		defer cacher.SafeUnlock(mu)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		if mu == nil {
+			return reply, nil
+		}
+	}
+
+	position := &model.Position{}
+	err := p.db.WithContext(ctx).Take(position, "id = ?", id).Error
+	switch err {
+	case nil:
+	case gorm.ErrRecordNotFound:
+		storeNil(p.cacheManager, cacheKey)
+		return nil, nil
+	default:
+		logger.Error(err)
+		return nil, err
+	}
+
+	err = p.cacheManager.StoreWithoutBlocking(cacher.NewItem(cacheKey, utils.Dump(position)))
+	if err != nil {
+		logger.Error(err)
+	}
+
+	return position, nil
+}
+
+// FindByCode find the position by its code, case-insensitively
+func (p *positionRepository) FindByCode(ctx context.Context, code string) (*model.Position, error) {
+	return p.findBy(ctx, "upper(code) = upper(?)", code)
+}
+
+// FindByTitle find the position by its title, case-insensitively
+func (p *positionRepository) FindByTitle(ctx context.Context, title string) (*model.Position, error) {
+	return p.findBy(ctx, "lower(title) = lower(?)", strings.TrimSpace(title))
+}
+
+func (p *positionRepository) FindAllByCriteria(ctx context.Context, criteria model.PositionCriteria) (positions []*model.Position, count int64, err error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      utils.DumpIncomingContext(ctx),
+		"criteria": utils.Dump(criteria),
+	})
+
+	var scopes []func(*gorm.DB) *gorm.DB
+	if criteria.Query != "" {
+		scopes = append(scopes, func(db *gorm.DB) *gorm.DB {
+			pattern := "%" + escapeLike(criteria.Query) + "%"
+			return db.Where("code ILIKE ? OR title ILIKE ?", pattern, pattern)
+		})
+	}
+
+	if criteria.Level > 0 {
+		scopes = append(scopes, func(db *gorm.DB) *gorm.DB {
+			return db.Where("level = ?", criteria.Level)
+		})
+	}
+
+	err = p.db.WithContext(ctx).Model(&model.Position{}).Scopes(scopes...).Count(&count).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	if count <= 0 {
+		return nil, 0, nil
+	}
+
+	err = p.db.WithContext(ctx).
+		Scopes(scopes...).
+		Scopes(scopeByPageAndLimit(criteria.Page, criteria.Size)).
+		Order("level ASC, title ASC, id ASC").
+		Find(&positions).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	return positions, count, nil
+}
+
+// FindAllTitles return the titles of the catalog positions, sorted
+func (p *positionRepository) FindAllTitles(ctx context.Context) ([]string, error) {
+	var titles []string
+	err := p.db.WithContext(ctx).
+		Model(&model.Position{}).
+		Order("title ASC").
+		Pluck("title", &titles).Error
+	if err != nil {
+		logrus.WithField("ctx", utils.DumpIncomingContext(ctx)).Error(err)
+		return nil, err
+	}
+
+	return titles, nil
+}
+
+// Update overwrite the position, model.ErrPositionInUse is returned when the title changes while employees
+// still hold the former one. The row is locked, so an employee can't take the former title in between.
+func (p *positionRepository) Update(ctx context.Context, position *model.Position) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      utils.DumpIncomingContext(ctx),
+		"position": utils.Dump(position),
+	})
+
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		before, err := findPositionForUpdate(tx, position.ID)
+		if err != nil {
+			return err
+		}
+
+		if !strings.EqualFold(before.Title, position.Title) {
+			if err := checkPositionNotInUse(tx, before.Title); err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&model.Position{}).
+			Where("id = ?", position.ID).
+			Updates(map[string]any{
+				"code":       position.Code,
+				"title":      position.Title,
+				"level":      position.Level,
+				"min_salary": position.MinSalary,
+				"max_salary": position.MaxSalary,
+			}).Error
+	})
+	switch err {
+	case nil:
+	case model.ErrPositionInUse:
+		return err
+	default:
+		logger.Error(err)
+		return err
+	}
+
+	if err := p.cacheManager.DeleteByKeys([]string{p.newCacheKeyByID(position.ID)}); err != nil {
+		logger.Error(err)
+	}
+
+	return nil
+}
+
+// Delete soft delete the position, model.ErrPositionInUse is returned while an employee holds it
+func (p *positionRepository) Delete(ctx context.Context, id int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.DumpIncomingContext(ctx),
+		"id":  id,
+	})
+
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		position, err := findPositionForUpdate(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := checkPositionNotInUse(tx, position.Title); err != nil {
+			return err
+		}
+
+		return tx.Model(&model.Position{}).Where("id = ?", id).Update("deleted_at", time.Now()).Error
+	})
+	switch err {
+	case nil:
+	case model.ErrPositionInUse:
+		return err
+	default:
+		logger.Error(err)
+		return err
+	}
+
+	if err := p.cacheManager.DeleteByKeys([]string{p.newCacheKeyByID(id)}); err != nil {
+		logger.Error(err)
+	}
+
+	return nil
+}
+
+func (p *positionRepository) findBy(ctx context.Context, query string, value string) (*model.Position, error) {
+	position := &model.Position{}
+	err := p.db.WithContext(ctx).Take(position, query, value).Error
+	switch err {
+	case nil:
+		return position, nil
+	case gorm.ErrRecordNotFound:
+		return nil, nil
+	default:
+		logrus.WithFields(logrus.Fields{
+			"ctx":   utils.DumpIncomingContext(ctx),
+			"value": value,
+		}).Error(err)
+		return nil, err
+	}
+}
+
+func (p *positionRepository) newCacheKeyByID(id int64) string {
+	return fmt.Sprintf("cache:object:position:id:%d", id)
+}
+
+// findPositionForUpdate find the position and lock the row until the transaction ends
+func findPositionForUpdate(tx *gorm.DB, id int64) (*model.Position, error) {
+	position := &model.Position{}
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(position, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return position, nil
+}
+
+func checkPositionNotInUse(tx *gorm.DB, title string) error {
+	var holders int64
+	err := tx.Model(&model.Employee{}).Where("lower(position) = lower(?)", title).Count(&holders).Error
+	if err != nil {
+		return err
+	}
+
+	if holders > 0 {
+		return model.ErrPositionInUse
+	}
+
+	return nil
+}
+
+// lockPosition share lock the catalog position of the title an employee holds until the transaction ends,
+// so it can't be renamed or deleted in between. model.ErrPositionNotFound is returned when it doesn't exist.
+func lockPosition(tx *gorm.DB, title string) error {
+	err := tx.Clauses(clause.Locking{Strength: "SHARE"}).Take(&model.Position{}, "lower(title) = lower(?)", title).Error
+	switch err {
+	case nil:
+		return nil
+	case gorm.ErrRecordNotFound:
+		return model.ErrPositionNotFound
+	default:
+		return err
+	}
+}
@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"github.com/irvankadhafi/employee-api/internal/auth"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/irvankadhafi/employee-api/utils"
+	"github.com/sirupsen/logrus"
+)
+
+type departmentUsecase struct {
+	departmentRepository model.DepartmentRepository
+}
+
+func NewDepartmentUsecase(repository model.DepartmentRepository) model.DepartmentUsecase {
+	return &departmentUsecase{departmentRepository: repository}
+}
+
+func (d *departmentUsecase) Create(ctx context.Context, input model.CreateDepartmentRequest) (department *model.Department, err error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.DumpIncomingContext(ctx),
+		"input": utils.Dump(input),
+	})
+
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionDepartmentCreate) {
+		return nil, ErrPermissionDenied
+	}
+
+	if err := input.Validate(); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if err := d.checkNameAvailable(ctx, input.Name, 0); err != nil {
+		return nil, err
+	}
+
+	department = &model.Department{
+		Name:        input.Name,
+		Description: input.Description,
+	}
+	if err := d.departmentRepository.Create(ctx, department); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return d.findByID(ctx, department.ID)
+}
+
+func (d *departmentUsecase) FindByID(ctx context.Context, id int64) (*model.Department, error) {
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionDepartmentRead) {
+		return nil, ErrPermissionDenied
+	}
+
+	return d.findByID(ctx, id)
+}
+
+func (d *departmentUsecase) FindAllByCriteria(ctx context.Context, criteria model.DepartmentCriteria) (departments []*model.Department, count int64, err error) {
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionDepartmentRead) {
+		return nil, 0, ErrPermissionDenied
+	}
+
+	criteria.SetDefaultValue()
+	departments, count, err = d.departmentRepository.FindAllByCriteria(ctx, criteria)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":      utils.DumpIncomingContext(ctx),
+			"criteria": utils.Dump(criteria),
+		}).Error(err)
+		return nil, 0, err
+	}
+
+	return departments, count, nil
+}
+
+func (d *departmentUsecase) Update(ctx context.Context, id int64, input model.UpdateDepartmentRequest) (department *model.Department, err error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.DumpIncomingContext(ctx),
+		"id":    id,
+		"input": utils.Dump(input),
+	})
+
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionDepartmentUpdate) {
+		return nil, ErrPermissionDenied
+	}
+
+	if err := input.Validate(); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	department, err = d.findByID(ctx, id)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if err := d.checkNameAvailable(ctx, input.Name, id); err != nil {
+		return nil, err
+	}
+
+	department.Name = input.Name
+	department.Description = input.Description
+	if err := d.departmentRepository.Update(ctx, department); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return d.findByID(ctx, id)
+}
+
+// DeleteByID delete the department, ErrDepartmentHasMembers is returned while employees belong to it
+func (d *departmentUsecase) DeleteByID(ctx context.Context, id int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.DumpIncomingContext(ctx),
+		"id":  id,
+	})
+
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionDepartmentDelete) {
+		return ErrPermissionDenied
+	}
+
+	if _, err := d.findByID(ctx, id); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	err := d.departmentRepository.Delete(ctx, id)
+	switch err {
+	case nil:
+		return nil
+	case model.ErrDepartmentHasMembers:
+		return ErrDepartmentHasMembers
+	default:
+		logger.Error(err)
+		return err
+	}
+}
+
+func (d *departmentUsecase) findByID(ctx context.Context, id int64) (*model.Department, error) {
+	department, err := d.departmentRepository.FindByID(ctx, id)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.DumpIncomingContext(ctx),
+			"id":  id,
+		}).Error(err)
+		return nil, err
+	}
+
+	if department == nil {
+		return nil, ErrNotFound
+	}
+
+	return department, nil
+}
+
+// checkNameAvailable check that no other department than exceptID has the name
+func (d *departmentUsecase) checkNameAvailable(ctx context.Context, name string, exceptID int64) error {
+	existing, err := d.departmentRepository.FindByName(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil && existing.ID != exceptID {
+		return ErrDuplicateDepartment
+	}
+
+	return nil
+}
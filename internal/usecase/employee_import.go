@@ -0,0 +1,227 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"github.com/irvankadhafi/employee-api/internal/auth"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/irvankadhafi/employee-api/utils"
+	"github.com/sirupsen/logrus"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// requiredImportColumns must be present in the header of an import
+var requiredImportColumns = []string{"name", "position", "salary"}
+
+// Import create the employees of the CSV rows, the first row is the header.
+// With opts.Upsert a row whose external_key belongs to an employee updates it instead.
+// A rejected row does not stop the import, it is reported in the result errors.
+func (e *employeeUsecase) Import(ctx context.Context, csvReader io.Reader, opts model.EmployeeImportOptions) (result *model.EmployeeImportResult, err error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":  utils.DumpIncomingContext(ctx),
+		"opts": utils.Dump(opts),
+	})
+
+	user := auth.GetUserFromCtx(ctx)
+	if !user.HasPermission(auth.PermissionEmployeeCreate) || (opts.Upsert && !user.HasPermission(auth.PermissionEmployeeUpdate)) {
+		return nil, ErrPermissionDenied
+	}
+
+	reader := csv.NewReader(csvReader)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		logger.Error(err)
+		return nil, ErrInvalidCSVHeader
+	}
+
+	columns, err := parseImportHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	result = &model.EmployeeImportResult{DryRun: opts.DryRun}
+	seenExternalKeys := map[string]int{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		result.Total++
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				logger.Error(err)
+				return nil, err
+			}
+			result.AddRowError(parseErr.Line, "", map[string]string{"_": parseErr.Err.Error()})
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		input, rowErrors := toCreateEmployeeRequest(columns, record)
+
+		var existing *model.Employee
+		if input.ExternalKey != "" {
+			existing, err = e.employeeRepository.FindByExternalKey(ctx, input.ExternalKey)
+			if err != nil {
+				logger.WithField("line", line).Error(err)
+				result.AddRowError(line, input.ExternalKey, map[string]string{"_": "failed to find the employee"})
+				continue
+			}
+		}
+
+		// an upserted employee keeps its currency when the row has none, the salary is in that currency
+		currency := model.BaseCurrency
+		if existing != nil && opts.Upsert {
+			currency = existing.Currency
+		}
+		input.Currency = model.NormalizeCurrencyOr(input.Currency, currency)
+		if rowErrors == nil {
+			if err := input.Validate(); err != nil {
+				for field, message := range validationErrorFields(err) {
+					rowErrors = mergeRowErrors(rowErrors, utils.ToSnakeCase(field), message)
+				}
+			}
+		}
+
+		if rowErrors == nil {
+			position, err := e.checkPosition(ctx, input.Position, input.Salary, input.Currency, false)
+			switch err {
+			case nil:
+				input.Position = position
+			case ErrPositionNotFound:
+				rowErrors = mergeRowErrors(rowErrors, "position", "not in the positions catalog")
+			case ErrSalaryOutOfBand:
+				rowErrors = mergeRowErrors(rowErrors, "salary", "out of the band of the position")
+			case ErrNoExchangeRate:
+				rowErrors = mergeRowErrors(rowErrors, "currency", "no exchange rate effective today")
+			default:
+				logger.WithField("line", line).Error(err)
+				rowErrors = mergeRowErrors(rowErrors, "_", "failed to check the position")
+			}
+		}
+
+		if input.ExternalKey != "" {
+			if firstLine, ok := seenExternalKeys[input.ExternalKey]; ok {
+				rowErrors = mergeRowErrors(rowErrors, "external_key", "duplicate of line "+strconv.Itoa(firstLine))
+			} else {
+				seenExternalKeys[input.ExternalKey] = line
+			}
+		}
+
+		if rowErrors != nil {
+			result.AddRowError(line, input.ExternalKey, rowErrors)
+			continue
+		}
+
+		created, err := e.importRow(ctx, input, existing, opts)
+		switch {
+		case err == ErrDuplicateEmployee:
+			result.AddRowError(line, input.ExternalKey, map[string]string{"external_key": "already exists"})
+		case err != nil:
+			logger.WithField("line", line).Error(err)
+			result.AddRowError(line, input.ExternalKey, map[string]string{"_": "failed to save the employee"})
+		case created:
+			result.Created++
+		default:
+			result.Updated++
+		}
+	}
+
+	return result, nil
+}
+
+// importRow create or, when upserting, update the existing employee of a valid row.
+// Nothing is written on a dry run but the outcome is still reported.
+func (e *employeeUsecase) importRow(ctx context.Context, input model.CreateEmployeeRequest, existing *model.Employee, opts model.EmployeeImportOptions) (created bool, err error) {
+	if existing == nil {
+		if opts.DryRun {
+			return true, nil
+		}
+
+		return true, e.employeeRepository.Create(ctx, newEmployee(input))
+	}
+
+	if !opts.Upsert {
+		return false, ErrDuplicateEmployee
+	}
+
+	if opts.DryRun {
+		return false, nil
+	}
+
+	existing.Name = input.Name
+	existing.Position = input.Position
+	existing.Salary = input.Salary
+	existing.Currency = input.Currency
+
+	return false, e.employeeRepository.Update(ctx, existing)
+}
+
+// parseImportHeader return the field of each column, the fields not imported are empty
+func parseImportHeader(header []string) ([]string, error) {
+	columns := make([]string, len(header))
+	found := map[string]bool{}
+	for idx, name := range header {
+		columns[idx] = model.NormalizeImportColumn(strings.TrimPrefix(name, "\ufeff"))
+		found[columns[idx]] = true
+	}
+
+	for _, column := range requiredImportColumns {
+		if !found[column] {
+			return nil, ErrInvalidCSVHeader
+		}
+	}
+
+	return columns, nil
+}
+
+func toCreateEmployeeRequest(columns []string, record []string) (input model.CreateEmployeeRequest, rowErrors map[string]string) {
+	for idx, value := range record {
+		if idx >= len(columns) {
+			break
+		}
+
+		value = strings.TrimSpace(value)
+		switch columns[idx] {
+		case "external_key":
+			input.ExternalKey = value
+		case "name":
+			input.Name = value
+		case "position":
+			input.Position = value
+		case "currency":
+			input.Currency = value
+		case "salary":
+			if value == "" {
+				continue
+			}
+
+			salary, err := model.ParseMoney(value)
+			if err != nil {
+				rowErrors = mergeRowErrors(rowErrors, "salary", "must be a number with at most 2 decimals")
+				continue
+			}
+			input.Salary = salary
+		}
+	}
+
+	return input, rowErrors
+}
+
+func mergeRowErrors(rowErrors map[string]string, column, message string) map[string]string {
+	if rowErrors == nil {
+		rowErrors = map[string]string{}
+	}
+	rowErrors[column] = message
+
+	return rowErrors
+}
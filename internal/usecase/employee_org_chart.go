@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"github.com/irvankadhafi/employee-api/internal/auth"
+	"github.com/irvankadhafi/employee-api/internal/config"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/irvankadhafi/employee-api/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// FindReports return the employee with the employees reporting to it, directly or not, down to depth levels
+func (e *employeeUsecase) FindReports(ctx context.Context, employeeID int64, depth int) (node *model.OrgChartNode, err error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":        utils.DumpIncomingContext(ctx),
+		"employeeID": employeeID,
+		"depth":      depth,
+	})
+
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionEmployeeRead) {
+		return nil, ErrPermissionDenied
+	}
+
+	if depth < 1 || depth > config.OrgChartMaxDepth() {
+		return nil, ErrInvalidDepth
+	}
+
+	node, err = e.employeeRepository.FindReportingTree(ctx, employeeID, depth)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if node == nil {
+		return nil, ErrNotFound
+	}
+
+	return node, nil
+}
+
+// GetOrgChart return the reporting trees of the whole company down to depth levels,
+// each tree starts from an employee without a manager
+func (e *employeeUsecase) GetOrgChart(ctx context.Context, depth int) (nodes []*model.OrgChartNode, err error) {
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionEmployeeRead) {
+		return nil, ErrPermissionDenied
+	}
+
+	if depth < 1 || depth > config.OrgChartMaxDepth() {
+		return nil, ErrInvalidDepth
+	}
+
+	nodes, err = e.employeeRepository.FindOrgChart(ctx, depth)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":   utils.DumpIncomingContext(ctx),
+			"depth": depth,
+		}).Error(err)
+		return nil, err
+	}
+
+	return nodes, nil
+}
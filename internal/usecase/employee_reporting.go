@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"github.com/irvankadhafi/employee-api/internal/config"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"time"
+)
+
+// loadReportingRates resolve the reporting currency and the rate date of a search normalizing the salaries, then load
+// the rates of every salary currency on that day into the criteria. The reporting currency defaults to
+// config.ReportingCurrency and the rate date to today. Nothing is loaded when the salaries aren't normalized.
+func (e *employeeUsecase) loadReportingRates(ctx context.Context, criteria *model.EmployeeSearchCriteria) error {
+	if !criteria.IsReporting() {
+		return nil
+	}
+
+	criteria.ReportingCurrency = model.NormalizeCurrencyOr(criteria.ReportingCurrency, config.ReportingCurrency())
+
+	if !model.IsCurrency(criteria.ReportingCurrency) {
+		return ErrInvalidCurrency
+	}
+
+	day := model.Today()
+	if criteria.RateDate != "" {
+		parsed, err := time.Parse(model.SalaryChangeDateLayout, criteria.RateDate)
+		if err != nil {
+			return ErrInvalidRateDate
+		}
+		day = parsed
+	}
+	criteria.RateDate = day.Format(model.SalaryChangeDateLayout)
+
+	currencies, err := e.employeeRepository.FindDistinctCurrencies(ctx)
+	if err != nil {
+		return err
+	}
+
+	criteria.Rates, err = findExchangeRates(ctx, e.exchangeRateRepository, append(currencies, criteria.ReportingCurrency), day)
+	return err
+}
+
+// setReportingSalaries set the salary of each employee normalized with the rates of the criteria. They are computed
+// by the database like the salary the search sorted on, so a cursor holds the very value the next page seeks past.
+// An employee whose currency got no rate because it was added after the rates were loaded is left as is.
+func (e *employeeUsecase) setReportingSalaries(ctx context.Context, criteria model.EmployeeSearchCriteria, employees []*model.Employee) error {
+	if criteria.Rates == nil || len(employees) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(employees))
+	for _, employee := range employees {
+		ids = append(ids, employee.ID)
+	}
+
+	salaries, err := e.employeeRepository.FindReportingSalaries(ctx, criteria, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, employee := range employees {
+		salary, ok := salaries[employee.ID]
+		if !ok {
+			continue
+		}
+
+		employee.ReportingSalary = &salary
+		employee.ReportingCurrency = criteria.ReportingCurrency
+	}
+
+	return nil
+}
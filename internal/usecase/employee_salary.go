@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"github.com/irvankadhafi/employee-api/internal/auth"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/irvankadhafi/employee-api/utils"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// FindSalaryHistory return the salary changes of the employee, the latest effective first
+func (e *employeeUsecase) FindSalaryHistory(ctx context.Context, criteria model.SalaryChangeCriteria) (changes []*model.SalaryChange, count int64, err error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      utils.DumpIncomingContext(ctx),
+		"criteria": utils.Dump(criteria),
+	})
+
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionEmployeeSalaryRead) {
+		return nil, 0, ErrPermissionDenied
+	}
+
+	if _, err := e.findByID(ctx, criteria.EmployeeID); err != nil {
+		return nil, 0, err
+	}
+
+	criteria.SetDefaultValue()
+	changes, count, err = e.employeeRepository.FindSalaryChangesByCriteria(ctx, criteria)
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	return changes, count, nil
+}
+
+// CreateSalaryChange record a salary change of the employee, approved by the caller. The amount is checked
+// against the band of the current position of the employee, it is in the currency of the employee unless the input
+// has one. A change effective today or before is applied right away, a later one by ApplyDueSalaryChanges once its
+// effective date is reached.
+func (e *employeeUsecase) CreateSalaryChange(ctx context.Context, employeeID int64, input model.CreateSalaryChangeRequest) (change *model.SalaryChange, err error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":        utils.DumpIncomingContext(ctx),
+		"employeeID": employeeID,
+		"input":      utils.DumpRedacted(input),
+	})
+
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionEmployeeSalaryChange) {
+		return nil, ErrPermissionDenied
+	}
+
+	input.Currency = model.NormalizeCurrencyOr(input.Currency, "")
+	if err := input.Validate(); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	employee, err := e.findByID(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	input.Currency = model.NormalizeCurrencyOr(input.Currency, employee.Currency)
+
+	if _, err := e.checkPosition(ctx, employee.Position, input.Amount, input.Currency, input.OverrideSalaryBand); err != nil {
+		return nil, err
+	}
+
+	effectiveFrom := model.Today()
+	if input.EffectiveFrom != "" {
+		// the layout is checked by the validation
+		effectiveFrom, _ = time.Parse(model.SalaryChangeDateLayout, input.EffectiveFrom)
+	}
+
+	change = &model.SalaryChange{
+		EmployeeID:    employeeID,
+		Amount:        input.Amount,
+		Currency:      input.Currency,
+		EffectiveFrom: effectiveFrom,
+		Reason:        input.Reason,
+	}
+	if err := e.employeeRepository.CreateSalaryChange(ctx, change); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return change, nil
+}
+
+// ApplyDueSalaryChanges apply the salary changes which became effective, it is run by the background job.
+// applied is the number of employees whose salary changed, an employee failing is logged and retried next time.
+func (e *employeeUsecase) ApplyDueSalaryChanges(ctx context.Context) (applied int, err error) {
+	day := model.Today()
+	ids, err := e.employeeRepository.FindEmployeeIDsWithDueSalaryChanges(ctx, day)
+	if err != nil {
+		logrus.WithField("day", day).Error(err)
+		return 0, err
+	}
+
+	for _, id := range ids {
+		ok, err := e.employeeRepository.ApplyDueSalaryChanges(ctx, id, day)
+		if err != nil {
+			logrus.WithField("employeeID", id).Error(err)
+			continue
+		}
+
+		if ok {
+			applied++
+		}
+	}
+
+	return applied, nil
+}
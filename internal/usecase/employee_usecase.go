@@ -1,47 +1,207 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/irvankadhafi/employee-api/internal/auth"
+	"github.com/irvankadhafi/employee-api/internal/config"
 	"github.com/irvankadhafi/employee-api/internal/model"
 	"github.com/irvankadhafi/employee-api/utils"
 	"github.com/sirupsen/logrus"
+	"math"
+	"strings"
 	"sync"
 )
 
 type employeeUsecase struct {
-	employeeRepository model.EmployeeRepository
+	employeeRepository     model.EmployeeRepository
+	departmentRepository   model.DepartmentRepository
+	positionRepository     model.PositionRepository
+	exchangeRateRepository model.ExchangeRateRepository
 }
 
-func NewEmployeeUsecase(repository model.EmployeeRepository) model.EmployeeUsecase {
-	return &employeeUsecase{employeeRepository: repository}
+func NewEmployeeUsecase(
+	repository model.EmployeeRepository,
+	departmentRepository model.DepartmentRepository,
+	positionRepository model.PositionRepository,
+	exchangeRateRepository model.ExchangeRateRepository,
+) model.EmployeeUsecase {
+	return &employeeUsecase{
+		employeeRepository:     repository,
+		departmentRepository:   departmentRepository,
+		positionRepository:     positionRepository,
+		exchangeRateRepository: exchangeRateRepository,
+	}
 }
 
 func (e *employeeUsecase) Create(ctx context.Context, input model.CreateEmployeeRequest) (employee *model.Employee, err error) {
 	logger := logrus.WithFields(logrus.Fields{
 		"ctx":   utils.DumpIncomingContext(ctx),
-		"input": utils.Dump(input),
+		"input": utils.DumpRedacted(input),
 	})
 
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionEmployeeCreate) {
+		return nil, ErrPermissionDenied
+	}
+
+	input.Currency = model.NormalizeCurrency(input.Currency)
 	if err := input.Validate(); err != nil {
 		logger.Error(err)
 		return nil, err
 	}
 
-	employee = &model.Employee{
-		Name:     input.Name,
-		Position: input.Position,
-		Salary:   input.Salary,
+	if input.ExternalKey != "" {
+		existing, err := e.employeeRepository.FindByExternalKey(ctx, input.ExternalKey)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		if existing != nil {
+			return nil, ErrDuplicateEmployee
+		}
+	}
+
+	input.Position, err = e.checkPosition(ctx, input.Position, input.Salary, input.Currency, input.OverrideSalaryBand)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := e.employeeRepository.Create(ctx, employee); err != nil {
+	if err := e.checkDepartment(ctx, input.DepartmentID); err != nil {
+		return nil, err
+	}
+
+	if err := e.checkManager(ctx, 0, input.ManagerID); err != nil {
+		return nil, err
+	}
+
+	employee = newEmployee(input)
+
+	err = e.employeeRepository.Create(ctx, employee)
+	switch err {
+	case nil:
+	case model.ErrDepartmentNotFound:
+		return nil, ErrDepartmentNotFound
+	case model.ErrManagerNotFound:
+		return nil, ErrManagerNotFound
+	case model.ErrPositionNotFound:
+		return nil, ErrPositionNotFound
+	default:
 		logger.Error(err)
 		return nil, err
 	}
 
-	return e.FindByID(ctx, employee.ID)
+	return e.findByID(ctx, employee.ID)
+}
+
+// BulkCreate create several employees. By default it is all or nothing: when an item is invalid or the insert fails
+// nothing is created and ErrBulkCreateRejected is returned along with the per item results.
+// With bestEffort every valid item is created on its own, regardless of the other items.
+func (e *employeeUsecase) BulkCreate(ctx context.Context, input model.BulkCreateEmployeeRequest, bestEffort bool) (results []*model.BulkCreateEmployeeResult, err error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":        utils.DumpIncomingContext(ctx),
+		"count":      len(input.Items),
+		"bestEffort": bestEffort,
+	})
+
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionEmployeeCreate) {
+		return nil, ErrPermissionDenied
+	}
+
+	if len(input.Items) == 0 {
+		return nil, ErrEmptyItems
+	}
+
+	if len(input.Items) > config.BulkCreateMaxItems() {
+		return nil, ErrTooManyItems
+	}
+
+	results = make([]*model.BulkCreateEmployeeResult, len(input.Items))
+	var (
+		employees  []*model.Employee
+		resultIdxs []int
+	)
+	for idx, item := range input.Items {
+		results[idx] = &model.BulkCreateEmployeeResult{Index: idx}
+		item.Currency = model.NormalizeCurrency(item.Currency)
+		if err := item.Validate(); err != nil {
+			results[idx].Status = model.BulkCreateStatusInvalid
+			results[idx].Errors = validationErrorFields(err)
+			continue
+		}
+
+		fields, err := e.checkBulkCreateItem(ctx, &item)
+		if err != nil {
+			logger.WithField("index", idx).Error(err)
+			results[idx].Status = model.BulkCreateStatusFailed
+			continue
+		}
+
+		if fields != nil {
+			results[idx].Status = model.BulkCreateStatusInvalid
+			results[idx].Errors = fields
+			continue
+		}
+
+		employees = append(employees, newEmployee(item))
+		resultIdxs = append(resultIdxs, idx)
+	}
+
+	if bestEffort {
+		for i, employee := range employees {
+			result := results[resultIdxs[i]]
+			if err := e.employeeRepository.Create(ctx, employee); err != nil {
+				logger.WithField("index", result.Index).Error(err)
+				result.Status = model.BulkCreateStatusFailed
+				continue
+			}
+
+			result.Status = model.BulkCreateStatusCreated
+			result.ID = employee.ID
+		}
+
+		return results, nil
+	}
+
+	if len(employees) < len(input.Items) {
+		markBulkCreateResults(results, resultIdxs, model.BulkCreateStatusSkipped)
+		return results, ErrBulkCreateRejected
+	}
+
+	err = e.employeeRepository.CreateInBatch(ctx, employees)
+	switch err {
+	case nil:
+	case model.ErrDepartmentNotFound:
+		return nil, ErrDepartmentNotFound
+	case model.ErrManagerNotFound:
+		return nil, ErrManagerNotFound
+	case model.ErrPositionNotFound:
+		return nil, ErrPositionNotFound
+	default:
+		logger.Error(err)
+		return nil, err
+	}
+
+	markBulkCreateResults(results, resultIdxs, model.BulkCreateStatusCreated)
+	for i, employee := range employees {
+		results[resultIdxs[i]].ID = employee.ID
+	}
+
+	return results, nil
 }
 
 func (e *employeeUsecase) FindByID(ctx context.Context, id int64) (employee *model.Employee, err error) {
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionEmployeeRead) {
+		return nil, ErrPermissionDenied
+	}
+
+	return e.findByID(ctx, id)
+}
+
+func (e *employeeUsecase) findByID(ctx context.Context, id int64) (employee *model.Employee, err error) {
 	logger := logrus.WithFields(logrus.Fields{
 		"ctx": utils.DumpIncomingContext(ctx),
 		"id":  id,
@@ -60,78 +220,432 @@ func (e *employeeUsecase) FindByID(ctx context.Context, id int64) (employee *mod
 	return employee, nil
 }
 
-func (e *employeeUsecase) Update(ctx context.Context, employeeID int64, input model.UpdateEmployeeRequest) (employee *model.Employee, err error) {
+func (e *employeeUsecase) Update(ctx context.Context, employeeID int64, input model.UpdateEmployeeRequest, expectedVersion int64) (employee *model.Employee, err error) {
 	logger := logrus.WithFields(logrus.Fields{
-		"ctx":        utils.DumpIncomingContext(ctx),
-		"employeeID": employeeID,
-		"input":      utils.Dump(input),
+		"ctx":             utils.DumpIncomingContext(ctx),
+		"employeeID":      employeeID,
+		"input":           utils.DumpRedacted(input),
+		"expectedVersion": expectedVersion,
 	})
 
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionEmployeeUpdate) {
+		return nil, ErrPermissionDenied
+	}
+
+	input.Currency = model.NormalizeCurrencyOr(input.Currency, "")
 	if err := input.Validate(); err != nil {
 		logger.Error(err)
 		return nil, err
 	}
 
-	employee, err = e.employeeRepository.FindByID(ctx, employeeID)
+	employee, err = e.findByID(ctx, employeeID)
 	if err != nil {
 		logger.Error(err)
 		return nil, err
 	}
 
-	employee.Name = input.Name
-	employee.Position = input.Position
+	if err := checkVersion(employee, expectedVersion); err != nil {
+		return nil, err
+	}
 
-	if err := e.employeeRepository.Update(ctx, employee); err != nil {
+	return e.replace(ctx, employee, input, expectedVersion)
+}
+
+func (e *employeeUsecase) Patch(ctx context.Context, employeeID int64, input model.PatchEmployeeRequest, expectedVersion int64) (employee *model.Employee, err error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":             utils.DumpIncomingContext(ctx),
+		"employeeID":      employeeID,
+		"input":           utils.DumpRedacted(json.RawMessage(input)),
+		"expectedVersion": expectedVersion,
+	})
+
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionEmployeeUpdate) {
+		return nil, ErrPermissionDenied
+	}
+
+	employee, err = e.findByID(ctx, employeeID)
+	if err != nil {
 		logger.Error(err)
 		return nil, err
 	}
 
-	return e.FindByID(ctx, employeeID)
+	if err := checkVersion(employee, expectedVersion); err != nil {
+		return nil, err
+	}
+
+	current := model.UpdateEmployeeRequest{
+		Name:         employee.Name,
+		Position:     employee.Position,
+		Salary:       employee.Salary,
+		Currency:     employee.Currency,
+		DepartmentID: employee.DepartmentID,
+		ManagerID:    employee.ManagerID,
+	}
+
+	patched, err := utils.MergePatch(utils.ToByte(current), input)
+	if err != nil {
+		logger.Error(err)
+		return nil, ErrInvalidPatch
+	}
+
+	// the patch may only touch the fields of UpdateEmployeeRequest
+	updated := model.UpdateEmployeeRequest{}
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&updated); err != nil {
+		logger.Error(err)
+		return nil, ErrInvalidPatch
+	}
+
+	updated.Currency = model.NormalizeCurrencyOr(updated.Currency, "")
+	if err := updated.Validate(); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return e.replace(ctx, employee, updated, expectedVersion)
 }
 
-func (e *employeeUsecase) DeleteByID(ctx context.Context, employeeID int64) (err error) {
+// replace overwrite the employee fields with the input and reload it, the currency is kept when the input has none.
+// The write only succeeds if the employee is still at the version it was read.
+func (e *employeeUsecase) replace(ctx context.Context, employee *model.Employee, input model.UpdateEmployeeRequest, expectedVersion int64) (*model.Employee, error) {
+	input.Currency = model.NormalizeCurrencyOr(input.Currency, employee.Currency)
+
+	if err := e.checkDepartment(ctx, input.DepartmentID); err != nil {
+		return nil, err
+	}
+
+	if err := e.checkManager(ctx, employee.ID, input.ManagerID); err != nil {
+		return nil, err
+	}
+
+	// an untouched position and salary stay valid even when the band has changed since
+	if !strings.EqualFold(input.Position, employee.Position) || input.Salary != employee.Salary || input.Currency != employee.Currency {
+		var err error
+		input.Position, err = e.checkPosition(ctx, input.Position, input.Salary, input.Currency, input.OverrideSalaryBand)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	employee.Name = input.Name
+	employee.Position = input.Position
+	employee.Salary = input.Salary
+	employee.Currency = input.Currency
+	employee.DepartmentID = input.DepartmentID
+	employee.ManagerID = input.ManagerID
+
+	err := e.employeeRepository.Update(ctx, employee)
+	switch err {
+	case nil:
+	case model.ErrVersionConflict:
+		return nil, versionConflictErr(expectedVersion)
+	case model.ErrDepartmentNotFound:
+		return nil, ErrDepartmentNotFound
+	case model.ErrManagerNotFound:
+		return nil, ErrManagerNotFound
+	case model.ErrManagerCycle:
+		return nil, ErrManagerCycle
+	case model.ErrPositionNotFound:
+		return nil, ErrPositionNotFound
+	default:
+		logrus.WithFields(logrus.Fields{
+			"ctx":      utils.DumpIncomingContext(ctx),
+			"employee": utils.DumpRedacted(employee),
+		}).Error(err)
+		return nil, err
+	}
+
+	return e.findByID(ctx, employee.ID)
+}
+
+func (e *employeeUsecase) DeleteByID(ctx context.Context, employeeID int64, expectedVersion int64) (err error) {
 	logger := logrus.WithFields(logrus.Fields{
-		"ctx":        utils.DumpIncomingContext(ctx),
-		"employeeID": employeeID,
+		"ctx":             utils.DumpIncomingContext(ctx),
+		"employeeID":      employeeID,
+		"expectedVersion": expectedVersion,
 	})
 
-	employee, err := e.FindByID(ctx, employeeID)
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionEmployeeDelete) {
+		return ErrPermissionDenied
+	}
+
+	employee, err := e.findByID(ctx, employeeID)
 	if err != nil {
 		logger.Error(err)
 		return err
 	}
 
-	if err := e.employeeRepository.Delete(ctx, employee.ID); err != nil {
-		logger.Error(err)
+	if err := checkVersion(employee, expectedVersion); err != nil {
 		return err
 	}
 
-	return nil
+	err = e.employeeRepository.Delete(ctx, employee.ID, employee.Version)
+	switch err {
+	case nil:
+		return nil
+	case model.ErrVersionConflict:
+		return versionConflictErr(expectedVersion)
+	default:
+		logger.Error(err)
+		return err
+	}
 }
 
 func (e *employeeUsecase) SearchByCriteria(ctx context.Context, searchCriteria model.EmployeeSearchCriteria) (employees []*model.Employee, count int64, err error) {
 	logger := logrus.WithFields(logrus.Fields{
 		"ctx":            utils.DumpIncomingContext(ctx),
-		"searchCriteria": utils.Dump(searchCriteria),
+		"searchCriteria": utils.DumpRedacted(searchCriteria),
 	})
 
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionEmployeeRead) {
+		return nil, 0, ErrPermissionDenied
+	}
+
+	searchCriteria.SetDefaultValue()
+	if err := searchCriteria.ParseSort(); err != nil {
+		return nil, 0, ErrInvalidSortField
+	}
+
+	if err := searchCriteria.Filter.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	if err := checkSalaryCriteria(ctx, searchCriteria); err != nil {
+		return nil, 0, err
+	}
+
+	if err := e.loadReportingRates(ctx, &searchCriteria); err != nil {
+		return nil, 0, err
+	}
+
 	ids, count, err := e.searchByPage(ctx, searchCriteria)
 	if err != nil {
 		logger.Error(err)
 		return
 	}
 
-	employees = e.findAllByIDs(ctx, ids)
+	employees, err = e.findAllByIDsForCriteria(ctx, searchCriteria, ids)
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
 	if len(employees) <= 0 {
 		logger.Error(ErrNotFound)
 		return
 	}
 
+	if err := e.setReportingSalaries(ctx, searchCriteria, employees); err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
 	return employees, count, nil
 }
 
+// SearchByCursor return the page following searchCriteria.Cursor, it seeks on the (sort column, id) key
+// so the pages stay stable while employees are added or removed. nextCursor is empty on the last page.
+func (e *employeeUsecase) SearchByCursor(ctx context.Context, searchCriteria model.EmployeeSearchCriteria) (employees []*model.Employee, nextCursor string, count int64, err error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":            utils.DumpIncomingContext(ctx),
+		"searchCriteria": utils.DumpRedacted(searchCriteria),
+	})
+
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionEmployeeRead) {
+		return nil, "", 0, ErrPermissionDenied
+	}
+
+	searchCriteria.SetDefaultValue()
+	if err := searchCriteria.ParseSort(); err != nil {
+		return nil, "", 0, ErrInvalidSortField
+	}
+
+	if err := searchCriteria.Filter.Validate(); err != nil {
+		return nil, "", 0, err
+	}
+
+	if err := checkSalaryCriteria(ctx, searchCriteria); err != nil {
+		return nil, "", 0, err
+	}
+
+	if err := e.loadReportingRates(ctx, &searchCriteria); err != nil {
+		return nil, "", 0, err
+	}
+
+	var cursor *model.EmployeeCursor
+	if searchCriteria.Cursor != "" {
+		cursor, err = model.DecodeEmployeeCursor(searchCriteria.Cursor)
+		if err != nil {
+			return nil, "", 0, ErrInvalidCursor
+		}
+
+		// the cursor only makes sense for the sort and the reporting currency it was created with
+		if model.FormatEmployeeSort(cursor.Sort) != model.FormatEmployeeSort(searchCriteria.Sort) || cursor.Reporting != searchCriteria.Reporting() {
+			return nil, "", 0, ErrInvalidCursor
+		}
+	}
+
+	ids, count, err := e.employeeRepository.SearchByCursor(ctx, searchCriteria, cursor)
+	if err != nil {
+		logger.Error(err)
+		return nil, "", 0, err
+	}
+
+	hasMore := int64(len(ids)) > searchCriteria.Size
+	if hasMore {
+		ids = ids[:searchCriteria.Size]
+	}
+
+	employees, err = e.findAllByIDsForCriteria(ctx, searchCriteria, ids)
+	if err != nil {
+		logger.Error(err)
+		return nil, "", 0, err
+	}
+	if err := e.setReportingSalaries(ctx, searchCriteria, employees); err != nil {
+		logger.Error(err)
+		return nil, "", 0, err
+	}
+
+	if hasMore && len(employees) > 0 {
+		nextCursor = model.NewEmployeeCursor(employees[len(employees)-1], searchCriteria.Sort, searchCriteria.Reporting()).Encode()
+	}
+
+	return employees, nextCursor, count, nil
+}
+
+// SearchByQuery return the page of employees matching searchCriteria.Query ranked by relevance,
+// each with its score and the fields highlighting the matched words
+func (e *employeeUsecase) SearchByQuery(ctx context.Context, searchCriteria model.EmployeeSearchCriteria) (results []*model.EmployeeSearchResult, count int64, err error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":            utils.DumpIncomingContext(ctx),
+		"searchCriteria": utils.DumpRedacted(searchCriteria),
+	})
+
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionEmployeeRead) {
+		return nil, 0, ErrPermissionDenied
+	}
+
+	searchCriteria.SetDefaultValue()
+	if err := searchCriteria.Filter.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	if err := checkSalaryCriteria(ctx, searchCriteria); err != nil {
+		return nil, 0, err
+	}
+
+	if err := e.loadReportingRates(ctx, &searchCriteria); err != nil {
+		return nil, 0, err
+	}
+
+	hits, count, err := e.employeeRepository.SearchByQuery(ctx, searchCriteria)
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	ids := make([]int64, 0, len(hits))
+	scores := map[int64]float64{}
+	for _, hit := range hits {
+		ids = append(ids, hit.ID)
+		scores[hit.ID] = hit.Score
+	}
+
+	employees, err := e.findAllByIDsForCriteria(ctx, searchCriteria, ids)
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	if err := e.setReportingSalaries(ctx, searchCriteria, employees); err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	terms := utils.SearchTerms(searchCriteria.Query)
+	for _, employee := range employees {
+		result := &model.EmployeeSearchResult{
+			Employee: employee,
+			Score:    math.Round(scores[employee.ID]*10000) / 10000,
+		}
+
+		for field, text := range map[string]string{"name": employee.Name, "position": employee.Position} {
+			if highlighted, ok := utils.Highlight(text, terms); ok {
+				if result.Highlights == nil {
+					result.Highlights = map[string]string{}
+				}
+				result.Highlights[field] = highlighted
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results, count, nil
+}
+
+// Export walk every employee matching the criteria filters in batches of config.ExportBatchSize,
+// fn is called once per batch so the caller can stream them out. Pagination and sorting are ignored.
+// The salaries are normalized like a search when the criteria has a reporting currency or a rate date.
+func (e *employeeUsecase) Export(ctx context.Context, criteria model.EmployeeSearchCriteria, fn func(employees []*model.Employee) error) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      utils.DumpIncomingContext(ctx),
+		"criteria": utils.DumpRedacted(criteria),
+	})
+
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionEmployeeRead) {
+		return ErrPermissionDenied
+	}
+
+	if err := criteria.Filter.Validate(); err != nil {
+		return err
+	}
+
+	if err := checkSalaryCriteria(ctx, criteria); err != nil {
+		return err
+	}
+
+	if err := e.loadReportingRates(ctx, &criteria); err != nil {
+		return err
+	}
+
+	batchSize := config.ExportBatchSize()
+	var afterID int64
+	for {
+		employees, err := e.employeeRepository.FindAllAfterID(ctx, criteria, afterID, batchSize)
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		if len(employees) == 0 {
+			return nil
+		}
+
+		if err := e.setReportingSalaries(ctx, criteria, employees); err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		if err := fn(employees); err != nil {
+			return err
+		}
+
+		if len(employees) < batchSize {
+			return nil
+		}
+		afterID = employees[len(employees)-1].ID
+	}
+}
+
+// GetDistinctPositions return the titles of the catalog positions, an employee can only hold one of them
 func (e *employeeUsecase) GetDistinctPositions(ctx context.Context) ([]string, error) {
-	positions, err := e.employeeRepository.GetDistinctPositions(ctx)
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionEmployeeRead) {
+		return nil, ErrPermissionDenied
+	}
+
+	positions, err := e.positionRepository.FindAllTitles(ctx)
 	if err != nil {
 		logrus.Error(err)
 		return nil, err
@@ -146,10 +660,53 @@ func (e *employeeUsecase) GetDistinctPositions(ctx context.Context) ([]string, e
 	return positions, nil
 }
 
+// Suggest return the most common values of a field starting with the prefix, for a type-ahead
+func (e *employeeUsecase) Suggest(ctx context.Context, criteria model.EmployeeSuggestCriteria) ([]*model.EmployeeSuggestion, error) {
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionEmployeeRead) {
+		return nil, ErrPermissionDenied
+	}
+
+	criteria.SetDefaultValue()
+	if err := criteria.Validate(); err != nil {
+		return nil, err
+	}
+
+	suggestions, err := e.employeeRepository.FindSuggestions(ctx, criteria)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":      utils.DumpIncomingContext(ctx),
+			"criteria": utils.Dump(criteria),
+		}).Error(err)
+		return nil, err
+	}
+
+	return suggestions, nil
+}
+
+func (e *employeeUsecase) FindHistoryByCriteria(ctx context.Context, criteria model.EmployeeAuditLogCriteria) (logs []*model.EmployeeAuditLog, count int64, err error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      utils.DumpIncomingContext(ctx),
+		"criteria": utils.DumpRedacted(criteria),
+	})
+
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionEmployeeAuditRead) {
+		return nil, 0, ErrPermissionDenied
+	}
+
+	criteria.SetDefaultValue()
+	logs, count, err = e.employeeRepository.FindAuditLogsByCriteria(ctx, criteria)
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	return logs, count, nil
+}
+
 func (e *employeeUsecase) searchByPage(ctx context.Context, searchCriteria model.EmployeeSearchCriteria) (ids []int64, count int64, err error) {
 	logger := logrus.WithFields(logrus.Fields{
 		"ctx":            utils.DumpIncomingContext(ctx),
-		"searchCriteria": utils.Dump(searchCriteria),
+		"searchCriteria": utils.DumpRedacted(searchCriteria),
 	})
 
 	searchCriteria.SetDefaultValue()
@@ -166,6 +723,33 @@ func (e *employeeUsecase) searchByPage(ctx context.Context, searchCriteria model
 	return ids, count, nil
 }
 
+// findAllByIDsForCriteria load the employees of a search, in the order of the IDs.
+// The deleted employees can't be found through the cache, they are loaded from the repository.
+func (e *employeeUsecase) findAllByIDsForCriteria(ctx context.Context, criteria model.EmployeeSearchCriteria, ids []int64) ([]*model.Employee, error) {
+	if !criteria.Filter.IncludeDeleted || len(ids) == 0 {
+		return e.findAllByIDs(ctx, ids), nil
+	}
+
+	employees, err := e.employeeRepository.FindAllByIDsWithDeleted(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	rs := map[int64]*model.Employee{}
+	for _, employee := range employees {
+		rs[employee.ID] = employee
+	}
+
+	sorted := make([]*model.Employee, 0, len(employees))
+	for _, id := range ids {
+		if employee, ok := rs[id]; ok {
+			sorted = append(sorted, employee)
+		}
+	}
+
+	return sorted, nil
+}
+
 func (e *employeeUsecase) findAllByIDs(ctx context.Context, ids []int64) (employees []*model.Employee) {
 	logger := logrus.WithFields(logrus.Fields{
 		"ctx": utils.DumpIncomingContext(ctx),
@@ -179,7 +763,7 @@ func (e *employeeUsecase) findAllByIDs(ctx context.Context, ids []int64) (employ
 		go func(id int64) {
 			defer wg.Done()
 
-			employee, err := e.FindByID(ctx, id)
+			employee, err := e.findByID(ctx, id)
 			if err != nil {
 				logger.Error(err)
 				return
@@ -209,3 +793,199 @@ func (e *employeeUsecase) findAllByIDs(ctx context.Context, ids []int64) (employ
 
 	return
 }
+
+// checkBulkCreateItem check the position, department and manager of a bulk creation item, the rejected field
+// is described like a validation error. The position of the item is set to the title of the catalog.
+func (e *employeeUsecase) checkBulkCreateItem(ctx context.Context, item *model.CreateEmployeeRequest) (fields map[string]string, err error) {
+	position, err := e.checkPosition(ctx, item.Position, item.Salary, item.Currency, item.OverrideSalaryBand)
+	switch err {
+	case nil:
+		item.Position = position
+	case ErrPositionNotFound:
+		return map[string]string{"Position": err.Error()}, nil
+	case ErrSalaryOutOfBand:
+		return map[string]string{"Salary": err.Error()}, nil
+	case ErrNoExchangeRate:
+		return map[string]string{"Currency": err.Error()}, nil
+	case ErrPermissionDenied:
+		return map[string]string{"OverrideSalaryBand": err.Error()}, nil
+	default:
+		return nil, err
+	}
+
+	switch err := e.checkDepartment(ctx, item.DepartmentID); err {
+	case nil:
+	case ErrDepartmentNotFound:
+		return map[string]string{"DepartmentID": err.Error()}, nil
+	default:
+		return nil, err
+	}
+
+	switch err := e.checkManager(ctx, 0, item.ManagerID); err {
+	case nil:
+	case ErrManagerNotFound:
+		return map[string]string{"ManagerID": err.Error()}, nil
+	default:
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// checkPosition find the catalog position of the title and check the salary is within its band, the band check
+// is skipped with overrideBand which requires the override permission. The band is in the base currency, a salary
+// in another currency is converted with the rate effective today, ErrNoExchangeRate is returned when there is none.
+// The title of the catalog is returned, so the case of the employee position always matches the catalog.
+func (e *employeeUsecase) checkPosition(ctx context.Context, title string, salary model.Money, currency string, overrideBand bool) (string, error) {
+	if overrideBand && !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionEmployeeSalaryBandOverride) {
+		return "", ErrPermissionDenied
+	}
+
+	position, err := e.positionRepository.FindByTitle(ctx, title)
+	if err != nil {
+		return "", err
+	}
+
+	if position == nil {
+		return "", ErrPositionNotFound
+	}
+
+	if overrideBand {
+		return position.Title, nil
+	}
+
+	if currency != model.BaseCurrency {
+		rates, err := findExchangeRates(ctx, e.exchangeRateRepository, []string{currency}, model.Today())
+		if err != nil {
+			return "", err
+		}
+
+		salary, _ = rates.Convert(salary, currency, model.BaseCurrency)
+	}
+
+	if !position.InBand(salary) {
+		return "", ErrSalaryOutOfBand
+	}
+
+	return position.Title, nil
+}
+
+// checkDepartment check the department an employee is assigned to exists, nil means no department
+func (e *employeeUsecase) checkDepartment(ctx context.Context, departmentID *int64) error {
+	if departmentID == nil {
+		return nil
+	}
+
+	department, err := e.departmentRepository.FindByID(ctx, *departmentID)
+	if err != nil {
+		return err
+	}
+
+	if department == nil {
+		return ErrDepartmentNotFound
+	}
+
+	return nil
+}
+
+// checkManager check the manager an employee reports to exists and isn't the employee or one of its reports,
+// employeeID is zero for a new employee. nil means no manager.
+func (e *employeeUsecase) checkManager(ctx context.Context, employeeID int64, managerID *int64) error {
+	if managerID == nil {
+		return nil
+	}
+
+	if *managerID == employeeID {
+		return ErrManagerCycle
+	}
+
+	chain, err := e.employeeRepository.FindManagerChain(ctx, *managerID)
+	if err != nil {
+		return err
+	}
+
+	if len(chain) == 0 {
+		return ErrManagerNotFound
+	}
+
+	for _, id := range chain {
+		if id == employeeID {
+			return ErrManagerCycle
+		}
+	}
+
+	return nil
+}
+
+// checkSalaryCriteria refuse the salary conditions, the salary sort and the reporting salaries of a search to the
+// callers who can't read the salary, they would reveal the masked salaries. A cursor would even carry the salary of
+// the last employee of its page. The sort must be parsed already.
+func checkSalaryCriteria(ctx context.Context, criteria model.EmployeeSearchCriteria) error {
+	if auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionEmployeeSalaryRead) {
+		return nil
+	}
+
+	if criteria.Filter.HasSalary() || criteria.IsReporting() || model.HasEmployeeSortColumn(criteria.Sort, "salary") {
+		return ErrPermissionDenied
+	}
+
+	return nil
+}
+
+// checkVersion check the employee against the version expected by the caller, zero expects any version
+func checkVersion(employee *model.Employee, expectedVersion int64) error {
+	if expectedVersion > 0 && employee.Version != expectedVersion {
+		return ErrPreconditionFailed
+	}
+
+	return nil
+}
+
+// versionConflictErr decide the error of a write which lost the race against a concurrent update,
+// it is a failed precondition when the caller asked for a specific version
+func versionConflictErr(expectedVersion int64) error {
+	if expectedVersion > 0 {
+		return ErrPreconditionFailed
+	}
+
+	return ErrConflict
+}
+
+func markBulkCreateResults(results []*model.BulkCreateEmployeeResult, idxs []int, status model.BulkCreateStatus) {
+	for _, idx := range idxs {
+		results[idx].Status = status
+	}
+}
+
+// validationErrorFields describe each failed field of the validation error,
+// an error which is not a validation error is reported under the "_" key
+func validationErrorFields(err error) map[string]string {
+	validationErrors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return map[string]string{"_": err.Error()}
+	}
+
+	fields := make(map[string]string)
+	for _, validationError := range validationErrors {
+		fields[validationError.Field()] = fmt.Sprintf("Failed on the '%s' tag", validationError.Tag())
+	}
+
+	return fields
+}
+
+func newEmployee(input model.CreateEmployeeRequest) *model.Employee {
+	employee := &model.Employee{
+		Name:         input.Name,
+		Position:     input.Position,
+		Salary:       input.Salary,
+		Currency:     input.Currency,
+		DepartmentID: input.DepartmentID,
+		ManagerID:    input.ManagerID,
+	}
+	if input.ExternalKey != "" {
+		externalKey := input.ExternalKey
+		employee.ExternalKey = &externalKey
+	}
+
+	return employee
+}
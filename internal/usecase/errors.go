@@ -3,7 +3,38 @@ package usecase
 import "errors"
 
 var (
-	ErrNotFound          = errors.New("not found")
-	ErrDuplicateEmployee = errors.New("employee already exist")
-	ErrPermissionDenied  = errors.New("permission denied")
+	ErrNotFound             = errors.New("not found")
+	ErrDuplicateEmployee    = errors.New("employee already exist")
+	ErrPermissionDenied     = errors.New("permission denied")
+	ErrInvalidPatch         = errors.New("invalid merge patch document")
+	ErrPreconditionFailed   = errors.New("precondition failed")
+	ErrConflict             = errors.New("conflict with a concurrent update")
+	ErrTooManyItems         = errors.New("too many items")
+	ErrEmptyItems           = errors.New("no item to process")
+	ErrBulkCreateRejected   = errors.New("bulk creation rejected, some items are invalid")
+	ErrInvalidCSVHeader     = errors.New("invalid csv header, the name, position and salary columns are required")
+	ErrInvalidCursor        = errors.New("invalid cursor")
+	ErrInvalidSortField     = errors.New("invalid sort field")
+	ErrDuplicateDepartment  = errors.New("department already exist")
+	ErrDepartmentHasMembers = errors.New("department still has employees")
+	ErrDepartmentNotFound   = errors.New("department not found")
+	ErrManagerNotFound      = errors.New("manager not found")
+	ErrManagerCycle         = errors.New("the employee can't report to itself or to one of its reports")
+	ErrInvalidDepth         = errors.New("invalid depth")
+	ErrDuplicatePosition    = errors.New("position already exist")
+	ErrPositionInUse        = errors.New("position still held by employees")
+	ErrPositionNotFound     = errors.New("position not found in the catalog")
+	ErrSalaryOutOfBand      = errors.New("salary out of the band of the position")
+	ErrInvalidPeriod        = errors.New("invalid period")
+	ErrNoRateTable          = errors.New("no payroll rate table for the period")
+	ErrNoSalaryForPeriod    = errors.New("the employee has no salary in the period")
+	ErrPayrollRunInProgress = errors.New("a payroll run of the period is already in progress")
+	ErrPayrollRunApproved   = errors.New("the payroll run of the period is already approved")
+	ErrPayrollRunReadOnly   = errors.New("the payroll run is approved, it is read-only")
+	ErrInvalidTransition    = errors.New("the payroll run can't move to this status from its current one")
+	ErrInvalidCurrency      = errors.New("invalid currency")
+	ErrInvalidRateDate      = errors.New("invalid rate date")
+	ErrNoExchangeRate       = errors.New("no exchange rate effective on the day for a currency")
+	ErrImportRejected       = errors.New("import rejected, some rows are invalid")
+	ErrInvalidRatesCSV      = errors.New("invalid csv header, the currency and rate columns are required")
 )
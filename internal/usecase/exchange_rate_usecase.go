@@ -0,0 +1,274 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"github.com/irvankadhafi/employee-api/internal/auth"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/irvankadhafi/employee-api/utils"
+	"github.com/sirupsen/logrus"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// requiredExchangeRateImportColumns must be present in the header of an exchange rates import
+var requiredExchangeRateImportColumns = []string{"currency", "rate"}
+
+type exchangeRateUsecase struct {
+	exchangeRateRepository model.ExchangeRateRepository
+}
+
+func NewExchangeRateUsecase(repository model.ExchangeRateRepository) model.ExchangeRateUsecase {
+	return &exchangeRateUsecase{exchangeRateRepository: repository}
+}
+
+// Create record the rate of a currency, the rate of the currency effective on the same day is replaced
+func (e *exchangeRateUsecase) Create(ctx context.Context, input model.CreateExchangeRateRequest) (rate *model.ExchangeRate, err error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.DumpIncomingContext(ctx),
+		"input": utils.Dump(input),
+	})
+
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionExchangeRateManage) {
+		return nil, ErrPermissionDenied
+	}
+
+	input.Currency = model.NormalizeCurrencyOr(input.Currency, "")
+	if err := input.Validate(); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	rate = newExchangeRate(input)
+	if err := e.exchangeRateRepository.Upsert(ctx, []*model.ExchangeRate{rate}); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return e.findByID(ctx, rate.ID)
+}
+
+func (e *exchangeRateUsecase) FindAllByCriteria(ctx context.Context, criteria model.ExchangeRateCriteria) (rates []*model.ExchangeRate, count int64, err error) {
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionExchangeRateRead) {
+		return nil, 0, ErrPermissionDenied
+	}
+
+	criteria.SetDefaultValue()
+	criteria.Currency = model.NormalizeCurrencyOr(criteria.Currency, "")
+	rates, count, err = e.exchangeRateRepository.FindAllByCriteria(ctx, criteria)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":      utils.DumpIncomingContext(ctx),
+			"criteria": utils.Dump(criteria),
+		}).Error(err)
+		return nil, 0, err
+	}
+
+	return rates, count, nil
+}
+
+// DeleteByID delete the rate, the previous rate of the currency is effective again from its day
+func (e *exchangeRateUsecase) DeleteByID(ctx context.Context, id int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.DumpIncomingContext(ctx),
+		"id":  id,
+	})
+
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionExchangeRateManage) {
+		return ErrPermissionDenied
+	}
+
+	if _, err := e.findByID(ctx, id); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if err := e.exchangeRateRepository.Delete(ctx, id); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Import record the rates of the CSV rows, the first row is the header with the currency, rate and optional
+// effective_from columns. It is all or nothing: ErrImportRejected is returned along with the result when a row
+// is rejected, and no rate is saved then.
+func (e *exchangeRateUsecase) Import(ctx context.Context, csvReader io.Reader) (result *model.ExchangeRateImportResult, err error) {
+	logger := logrus.WithField("ctx", utils.DumpIncomingContext(ctx))
+
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionExchangeRateManage) {
+		return nil, ErrPermissionDenied
+	}
+
+	reader := csv.NewReader(csvReader)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		logger.Error(err)
+		return nil, ErrInvalidRatesCSV
+	}
+
+	columns, err := parseExchangeRateImportHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	result = &model.ExchangeRateImportResult{}
+	var rates []*model.ExchangeRate
+	seen := map[string]int{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		result.Total++
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				logger.Error(err)
+				return nil, err
+			}
+			result.AddRowError(parseErr.Line, map[string]string{"_": parseErr.Err.Error()})
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		input, rowErrors := toCreateExchangeRateRequest(columns, record)
+		if rowErrors == nil {
+			if err := input.Validate(); err != nil {
+				for field, message := range validationErrorFields(err) {
+					rowErrors = mergeRowErrors(rowErrors, utils.ToSnakeCase(field), message)
+				}
+			}
+		}
+
+		if rowErrors != nil {
+			result.AddRowError(line, rowErrors)
+			continue
+		}
+
+		rate := newExchangeRate(input)
+		key := rate.Currency + "@" + rate.EffectiveFrom.Format(model.SalaryChangeDateLayout)
+		if firstLine, ok := seen[key]; ok {
+			result.AddRowError(line, map[string]string{"effective_from": "duplicate of line " + strconv.Itoa(firstLine)})
+			continue
+		}
+		seen[key] = line
+
+		rates = append(rates, rate)
+	}
+
+	if result.Rejected > 0 {
+		return result, ErrImportRejected
+	}
+
+	if err := e.exchangeRateRepository.Upsert(ctx, rates); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	result.Imported = len(rates)
+
+	return result, nil
+}
+
+func (e *exchangeRateUsecase) findByID(ctx context.Context, id int64) (*model.ExchangeRate, error) {
+	rate, err := e.exchangeRateRepository.FindByID(ctx, id)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.DumpIncomingContext(ctx),
+			"id":  id,
+		}).Error(err)
+		return nil, err
+	}
+
+	if rate == nil {
+		return nil, ErrNotFound
+	}
+
+	return rate, nil
+}
+
+// findExchangeRates load the rates of the currencies effective on the day, ErrNoExchangeRate is returned
+// when one of them has no rate yet
+func findExchangeRates(ctx context.Context, repository model.ExchangeRateRepository, currencies []string, day time.Time) (*model.ExchangeRates, error) {
+	rates, err := repository.FindRatesOn(ctx, currencies, day)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, currency := range currencies {
+		if _, ok := rates.Rate(currency); !ok {
+			return nil, ErrNoExchangeRate
+		}
+	}
+
+	return rates, nil
+}
+
+// parseExchangeRateImportHeader return the field of each column, the fields not imported are empty
+func parseExchangeRateImportHeader(header []string) ([]string, error) {
+	columns := make([]string, len(header))
+	found := map[string]bool{}
+	for idx, name := range header {
+		columns[idx] = model.NormalizeExchangeRateImportColumn(strings.TrimPrefix(name, "\ufeff"))
+		found[columns[idx]] = true
+	}
+
+	for _, column := range requiredExchangeRateImportColumns {
+		if !found[column] {
+			return nil, ErrInvalidRatesCSV
+		}
+	}
+
+	return columns, nil
+}
+
+func toCreateExchangeRateRequest(columns []string, record []string) (input model.CreateExchangeRateRequest, rowErrors map[string]string) {
+	for idx, value := range record {
+		if idx >= len(columns) {
+			break
+		}
+
+		value = strings.TrimSpace(value)
+		switch columns[idx] {
+		case "currency":
+			input.Currency = model.NormalizeCurrencyOr(value, "")
+		case "rate":
+			if value == "" {
+				continue
+			}
+
+			rate, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				rowErrors = mergeRowErrors(rowErrors, "rate", "must be a number")
+				continue
+			}
+			input.Rate = rate
+		case "effective_from":
+			input.EffectiveFrom = value
+		}
+	}
+
+	return input, rowErrors
+}
+
+func newExchangeRate(input model.CreateExchangeRateRequest) *model.ExchangeRate {
+	effectiveFrom := model.Today()
+	if input.EffectiveFrom != "" {
+		effectiveFrom, _ = time.Parse(model.SalaryChangeDateLayout, input.EffectiveFrom)
+	}
+
+	return &model.ExchangeRate{
+		Currency:      input.Currency,
+		Rate:          input.Rate,
+		EffectiveFrom: effectiveFrom,
+	}
+}
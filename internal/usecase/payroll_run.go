@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"context"
+	"github.com/irvankadhafi/employee-api/internal/auth"
+	"github.com/irvankadhafi/employee-api/internal/config"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/irvankadhafi/employee-api/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// payrollRunStatusPermissions the permission needed to move a run to each status
+var payrollRunStatusPermissions = map[model.PayrollRunStatus]auth.Permission{
+	model.PayrollRunStatusReviewed: auth.PermissionPayrollRun,
+	model.PayrollRunStatusApproved: auth.PermissionPayrollApprove,
+	model.PayrollRunStatusPaid:     auth.PermissionPayrollApprove,
+}
+
+// CreateRun create a draft payroll run of the YYYY-MM period, snapshotting the payslip of every active employee
+// computed in batches of config.ExportBatchSize. The employees without a salary in the period are left out.
+// ErrPayrollRunInProgress is returned when a run of the period is already in draft or reviewed, ErrPayrollRunApproved
+// when it is already approved or paid: a period is run once.
+func (p *payrollUsecase) CreateRun(ctx context.Context, period string) (run *model.PayrollRun, err error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.DumpIncomingContext(ctx),
+		"period": period,
+	})
+
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionPayrollRun) {
+		return nil, ErrPermissionDenied
+	}
+
+	start, table, err := p.findRateTable(period)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := p.payrollRepository.FindRunByPeriod(ctx, period)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	switch {
+	case existing == nil:
+		break
+	case existing.Status.IsInProgress():
+		return nil, ErrPayrollRunInProgress
+	default:
+		return nil, ErrPayrollRunApproved
+	}
+
+	run = &model.PayrollRun{
+		Period:           period,
+		Status:           model.PayrollRunStatusDraft,
+		RateTableVersion: table.Version,
+	}
+	var items []*model.PayrollRunItem
+	batchSize := config.ExportBatchSize()
+	var afterID int64
+	for {
+		employees, err := p.employeeRepository.FindAllAfterID(ctx, model.EmployeeSearchCriteria{}, afterID, batchSize)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		payslips, err := p.calculate(ctx, table, start, employees)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		for _, payslip := range payslips {
+			run.Add(payslip)
+			items = append(items, &model.PayrollRunItem{
+				EmployeeID: payslip.EmployeeID,
+				NetPay:     payslip.NetPay,
+				Payslip:    payslip,
+			})
+		}
+
+		if len(employees) < batchSize {
+			break
+		}
+		afterID = employees[len(employees)-1].ID
+	}
+
+	err = p.payrollRepository.CreateRun(ctx, run, items)
+	switch err {
+	case nil:
+		return run, nil
+	case model.ErrPayrollRunInProgress:
+		return nil, ErrPayrollRunInProgress
+	case model.ErrPayrollRunApproved:
+		return nil, ErrPayrollRunApproved
+	default:
+		logger.Error(err)
+		return nil, err
+	}
+}
+
+// FindRunByID :nodoc:
+func (p *payrollUsecase) FindRunByID(ctx context.Context, id int64) (run *model.PayrollRun, err error) {
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionPayrollRead) {
+		return nil, ErrPermissionDenied
+	}
+
+	return p.findRunByID(ctx, id)
+}
+
+// FindRunsByCriteria return the payroll runs matching the period and the status, the latest first
+func (p *payrollUsecase) FindRunsByCriteria(ctx context.Context, criteria model.PayrollRunCriteria) (runs []*model.PayrollRun, count int64, err error) {
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionPayrollRead) {
+		return nil, 0, ErrPermissionDenied
+	}
+
+	if criteria.Period != "" {
+		if _, err := model.ParsePayrollPeriod(criteria.Period); err != nil {
+			return nil, 0, ErrInvalidPeriod
+		}
+	}
+
+	criteria.SetDefaultValue()
+	runs, count, err = p.payrollRepository.FindRunsByCriteria(ctx, criteria)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":      utils.DumpIncomingContext(ctx),
+			"criteria": utils.Dump(criteria),
+		}).Error(err)
+		return nil, 0, err
+	}
+
+	return runs, count, nil
+}
+
+// FindRunItemsByCriteria return the payslips snapshot by the payroll run
+func (p *payrollUsecase) FindRunItemsByCriteria(ctx context.Context, criteria model.PayrollRunItemCriteria) (items []*model.PayrollRunItem, count int64, err error) {
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionPayrollRead) {
+		return nil, 0, ErrPermissionDenied
+	}
+
+	if _, err := p.findRunByID(ctx, criteria.PayrollRunID); err != nil {
+		return nil, 0, err
+	}
+
+	criteria.SetDefaultValue()
+	items, count, err = p.payrollRepository.FindRunItemsByCriteria(ctx, criteria)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":      utils.DumpIncomingContext(ctx),
+			"criteria": utils.Dump(criteria),
+		}).Error(err)
+		return nil, 0, err
+	}
+
+	return items, count, nil
+}
+
+// MoveRun move the payroll run to the next status: a draft is reviewed, a reviewed run is approved
+// and an approved one is paid. ErrInvalidTransition is returned for any other move.
+func (p *payrollUsecase) MoveRun(ctx context.Context, id int64, status model.PayrollRunStatus) (run *model.PayrollRun, err error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.DumpIncomingContext(ctx),
+		"id":     id,
+		"status": status,
+	})
+
+	permission, ok := payrollRunStatusPermissions[status]
+	if !ok {
+		return nil, ErrInvalidTransition
+	}
+
+	if !auth.GetUserFromCtx(ctx).HasPermission(permission) {
+		return nil, ErrPermissionDenied
+	}
+
+	run, err = p.findRunByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	from := run.Status
+	if !from.CanMoveTo(status) {
+		return nil, ErrInvalidTransition
+	}
+
+	run.Status = status
+	err = p.payrollRepository.UpdateRunStatus(ctx, run, from)
+	switch err {
+	case nil:
+		return run, nil
+	case model.ErrPayrollRunStatusChanged:
+		return nil, ErrInvalidTransition
+	default:
+		logger.Error(err)
+		return nil, err
+	}
+}
+
+// DeleteRun delete a draft or reviewed payroll run, ErrPayrollRunReadOnly is returned once it is approved
+func (p *payrollUsecase) DeleteRun(ctx context.Context, id int64) (err error) {
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionPayrollRun) {
+		return ErrPermissionDenied
+	}
+
+	run, err := p.findRunByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !run.Status.IsInProgress() {
+		return ErrPayrollRunReadOnly
+	}
+
+	err = p.payrollRepository.DeleteRun(ctx, id)
+	switch err {
+	case nil:
+		return nil
+	case model.ErrPayrollRunReadOnly:
+		return ErrPayrollRunReadOnly
+	default:
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.DumpIncomingContext(ctx),
+			"id":  id,
+		}).Error(err)
+		return err
+	}
+}
+
+func (p *payrollUsecase) findRunByID(ctx context.Context, id int64) (*model.PayrollRun, error) {
+	run, err := p.payrollRepository.FindRunByID(ctx, id)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.DumpIncomingContext(ctx),
+			"id":  id,
+		}).Error(err)
+		return nil, err
+	}
+
+	if run == nil {
+		return nil, ErrNotFound
+	}
+
+	return run, nil
+}
@@ -0,0 +1,392 @@
+package usecase
+
+import (
+	"context"
+	"github.com/irvankadhafi/employee-api/internal/auth"
+	"github.com/irvankadhafi/employee-api/internal/config"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/irvankadhafi/employee-api/internal/payroll"
+	"github.com/irvankadhafi/employee-api/utils"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+type payrollUsecase struct {
+	employeeRepository     model.EmployeeRepository
+	payrollRepository      model.PayrollRepository
+	exchangeRateRepository model.ExchangeRateRepository
+	rateTables             payroll.RateTables
+}
+
+func NewPayrollUsecase(
+	employeeRepository model.EmployeeRepository,
+	payrollRepository model.PayrollRepository,
+	exchangeRateRepository model.ExchangeRateRepository,
+	rateTables payroll.RateTables,
+) model.PayrollUsecase {
+	return &payrollUsecase{
+		employeeRepository:     employeeRepository,
+		payrollRepository:      payrollRepository,
+		exchangeRateRepository: exchangeRateRepository,
+		rateTables:             rateTables,
+	}
+}
+
+// GetPayslip return the payslip of the employee for the YYYY-MM period. It is the snapshot of the approved payroll run
+// of the period, otherwise it is computed with the salary effective on the last day of the period.
+// ErrNoSalaryForPeriod is returned when the employee had no salary yet. An employee reads its own payslips
+// without the payroll permission.
+func (p *payrollUsecase) GetPayslip(ctx context.Context, employeeID int64, period string) (payslip *model.Payslip, err error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":        utils.DumpIncomingContext(ctx),
+		"employeeID": employeeID,
+		"period":     period,
+	})
+
+	user := auth.GetUserFromCtx(ctx)
+	if !user.HasPermission(auth.PermissionPayrollRead) && !user.IsEmployee(employeeID) {
+		return nil, ErrPermissionDenied
+	}
+
+	start, table, err := p.findRateTable(period)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := p.payrollRepository.FindPaidOutItem(ctx, employeeID, period)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if item != nil {
+		return item.Payslip, nil
+	}
+
+	employee, err := p.employeeRepository.FindByID(ctx, employeeID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if employee == nil {
+		return nil, ErrNotFound
+	}
+
+	payslips, err := p.calculate(ctx, table, start, []*model.Employee{employee})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if len(payslips) == 0 {
+		return nil, ErrNoSalaryForPeriod
+	}
+
+	return payslips[0], nil
+}
+
+// ExportPayslips pass the payslips of the YYYY-MM period to fn, in batches of config.ExportBatchSize. They are
+// the snapshot of the approved payroll run of the period, otherwise they are computed for every active employee
+// having a salary in the period.
+func (p *payrollUsecase) ExportPayslips(ctx context.Context, period string, fn func(payslips []*model.Payslip) error) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.DumpIncomingContext(ctx),
+		"period": period,
+	})
+
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionPayrollRead) {
+		return ErrPermissionDenied
+	}
+
+	start, table, err := p.findRateTable(period)
+	if err != nil {
+		return err
+	}
+
+	run, err := p.payrollRepository.FindPaidOutRunByPeriod(ctx, period)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	batchSize := config.ExportBatchSize()
+	if run != nil {
+		criteria := model.PayrollRunItemCriteria{PayrollRunID: run.ID, Page: 1, Size: int64(batchSize)}
+		for {
+			items, _, err := p.payrollRepository.FindRunItemsByCriteria(ctx, criteria)
+			if err != nil {
+				logger.Error(err)
+				return err
+			}
+
+			if len(items) == 0 {
+				return nil
+			}
+
+			payslips := make([]*model.Payslip, 0, len(items))
+			for _, item := range items {
+				payslips = append(payslips, item.Payslip)
+			}
+
+			if err := fn(payslips); err != nil {
+				return err
+			}
+
+			if len(items) < batchSize {
+				return nil
+			}
+			criteria.Page++
+		}
+	}
+
+	var afterID int64
+	for {
+		employees, err := p.employeeRepository.FindAllAfterID(ctx, model.EmployeeSearchCriteria{}, afterID, batchSize)
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		payslips, err := p.calculate(ctx, table, start, employees)
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		if len(payslips) > 0 {
+			if err := fn(payslips); err != nil {
+				return err
+			}
+		}
+
+		if len(employees) < batchSize {
+			return nil
+		}
+		afterID = employees[len(employees)-1].ID
+	}
+}
+
+// FindProfile return the payroll profile of the employee, the default one when it has none
+func (p *payrollUsecase) FindProfile(ctx context.Context, employeeID int64) (profile *model.PayrollProfile, err error) {
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionPayrollRead) {
+		return nil, ErrPermissionDenied
+	}
+
+	return p.findProfile(ctx, employeeID)
+}
+
+// UpdateProfile replace the PTKP status, the allowances and the deductions of the employee
+func (p *payrollUsecase) UpdateProfile(ctx context.Context, employeeID int64, input model.UpdatePayrollProfileRequest) (profile *model.PayrollProfile, err error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":        utils.DumpIncomingContext(ctx),
+		"employeeID": employeeID,
+		"input":      utils.DumpRedacted(input),
+	})
+
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionPayrollProfileUpdate) {
+		return nil, ErrPermissionDenied
+	}
+
+	if err := input.Validate(); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if _, err := p.findProfile(ctx, employeeID); err != nil {
+		return nil, err
+	}
+
+	err = p.payrollRepository.UpsertProfile(ctx, &model.PayrollProfile{
+		EmployeeID: employeeID,
+		PTKPStatus: input.PTKPStatus,
+		Allowances: input.Allowances,
+		Deductions: input.Deductions,
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return p.findProfile(ctx, employeeID)
+}
+
+func (p *payrollUsecase) findProfile(ctx context.Context, employeeID int64) (*model.PayrollProfile, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":        utils.DumpIncomingContext(ctx),
+		"employeeID": employeeID,
+	})
+
+	employee, err := p.employeeRepository.FindByID(ctx, employeeID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if employee == nil {
+		return nil, ErrNotFound
+	}
+
+	profile, err := p.payrollRepository.FindProfileByEmployeeID(ctx, employeeID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if profile == nil {
+		return model.NewDefaultPayrollProfile(employeeID), nil
+	}
+
+	return profile, nil
+}
+
+// findRateTable parse the period into its first day and find the rate table effective on it
+func (p *payrollUsecase) findRateTable(period string) (time.Time, *payroll.RateTable, error) {
+	start, err := model.ParsePayrollPeriod(period)
+	if err != nil {
+		return time.Time{}, nil, ErrInvalidPeriod
+	}
+
+	table, err := p.rateTables.ForPeriod(start)
+	if err != nil {
+		return time.Time{}, nil, ErrNoRateTable
+	}
+
+	return start, table, nil
+}
+
+// calculate compute the payslips of the employees for the period starting on start, each with the salary effective
+// on the last day of the period and its payroll profile. The employees without a salary then are left out.
+// The salaries not paid in the BaseCurrency are converted with the rates effective on that day too,
+// ErrNoExchangeRate is returned when one of their currencies has no rate then. The PPh 21 of December reconciles
+// the year with the payslips of the previous months.
+func (p *payrollUsecase) calculate(ctx context.Context, table *payroll.RateTable, start time.Time, employees []*model.Employee) ([]*model.Payslip, error) {
+	if len(employees) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int64, 0, len(employees))
+	for _, employee := range employees {
+		ids = append(ids, employee.ID)
+	}
+
+	end := start.AddDate(0, 1, -1)
+	salaries, err := p.employeeRepository.FindSalariesOn(ctx, ids, end)
+	if err != nil {
+		return nil, err
+	}
+
+	var currencies []string
+	for _, salary := range salaries {
+		if salary.Currency != model.BaseCurrency {
+			currencies = append(currencies, salary.Currency)
+		}
+	}
+
+	rates := model.NewExchangeRates(end)
+	if len(currencies) > 0 {
+		rates, err = findExchangeRates(ctx, p.exchangeRateRepository, currencies, end)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	profiles, err := p.payrollRepository.FindProfilesByEmployeeIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	profileByEmployeeID := make(map[int64]*model.PayrollProfile, len(profiles))
+	for _, profile := range profiles {
+		profileByEmployeeID[profile.EmployeeID] = profile
+	}
+
+	var yearToDate map[int64]payroll.YearToDate
+	if start.Month() == time.December {
+		yearToDate, err = p.findYearToDate(ctx, start, employees)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	period := start.Format(model.PayrollPeriodLayout)
+	payslips := make([]*model.Payslip, 0, len(employees))
+	for _, employee := range employees {
+		salary, ok := salaries[employee.ID]
+		if !ok {
+			continue
+		}
+
+		profile, ok := profileByEmployeeID[employee.ID]
+		if !ok {
+			profile = model.NewDefaultPayrollProfile(employee.ID)
+		}
+
+		baseSalary, err := rates.Convert(salary.Amount, salary.Currency, model.BaseCurrency)
+		if err != nil {
+			return nil, err
+		}
+
+		payslip := payroll.Calculate(table, start.Month(), baseSalary, profile, yearToDate[employee.ID])
+		if salary.Currency != model.BaseCurrency {
+			payslip.Salary = &salary.Amount
+			payslip.SalaryCurrency = salary.Currency
+			payslip.ExchangeRate, _ = rates.Rate(salary.Currency)
+		}
+		payslip.EmployeeID = employee.ID
+		payslip.EmployeeName = employee.Name
+		payslip.Position = employee.Position
+		payslip.Period = period
+		payslips = append(payslips, payslip)
+	}
+
+	return payslips, nil
+}
+
+// findYearToDate sum the payslips of the employees from January to the month before the one starting on start. The
+// payslips of a month are the snapshot of its approved payroll run, otherwise they are computed like calculate does.
+func (p *payrollUsecase) findYearToDate(ctx context.Context, start time.Time, employees []*model.Employee) (map[int64]payroll.YearToDate, error) {
+	ids := make([]int64, 0, len(employees))
+	for _, employee := range employees {
+		ids = append(ids, employee.ID)
+	}
+
+	yearToDate := make(map[int64]payroll.YearToDate, len(employees))
+	for month := time.Date(start.Year(), time.January, 1, 0, 0, 0, 0, start.Location()); month.Before(start); month = month.AddDate(0, 1, 0) {
+		// no payslip is computed for a month before the first rate table
+		table, err := p.rateTables.ForPeriod(month)
+		if err != nil {
+			continue
+		}
+
+		run, err := p.payrollRepository.FindPaidOutRunByPeriod(ctx, month.Format(model.PayrollPeriodLayout))
+		if err != nil {
+			return nil, err
+		}
+
+		var payslips []*model.Payslip
+		if run != nil {
+			items, err := p.payrollRepository.FindRunItemsByEmployeeIDs(ctx, run.ID, ids)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, item := range items {
+				payslips = append(payslips, item.Payslip)
+			}
+		} else {
+			payslips, err = p.calculate(ctx, table, month, employees)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, payslip := range payslips {
+			sum := yearToDate[payslip.EmployeeID]
+			sum.Add(payslip)
+			yearToDate[payslip.EmployeeID] = sum
+		}
+	}
+
+	return yearToDate, nil
+}
@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"context"
+	"github.com/irvankadhafi/employee-api/internal/auth"
+	"github.com/irvankadhafi/employee-api/internal/model"
+	"github.com/irvankadhafi/employee-api/utils"
+	"github.com/sirupsen/logrus"
+	"strings"
+)
+
+type positionUsecase struct {
+	positionRepository model.PositionRepository
+}
+
+func NewPositionUsecase(repository model.PositionRepository) model.PositionUsecase {
+	return &positionUsecase{positionRepository: repository}
+}
+
+func (p *positionUsecase) Create(ctx context.Context, input model.CreatePositionRequest) (position *model.Position, err error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.DumpIncomingContext(ctx),
+		"input": utils.Dump(input),
+	})
+
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionPositionCreate) {
+		return nil, ErrPermissionDenied
+	}
+
+	if err := input.Validate(); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	position = &model.Position{
+		Code:      strings.ToUpper(strings.TrimSpace(input.Code)),
+		Title:     strings.TrimSpace(input.Title),
+		Level:     input.Level,
+		MinSalary: input.MinSalary,
+		MaxSalary: input.MaxSalary,
+	}
+	if err := p.checkAvailable(ctx, position); err != nil {
+		return nil, err
+	}
+
+	if err := p.positionRepository.Create(ctx, position); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return p.findByID(ctx, position.ID)
+}
+
+func (p *positionUsecase) FindByID(ctx context.Context, id int64) (*model.Position, error) {
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionPositionRead) {
+		return nil, ErrPermissionDenied
+	}
+
+	return p.findByID(ctx, id)
+}
+
+func (p *positionUsecase) FindAllByCriteria(ctx context.Context, criteria model.PositionCriteria) (positions []*model.Position, count int64, err error) {
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionPositionRead) {
+		return nil, 0, ErrPermissionDenied
+	}
+
+	criteria.SetDefaultValue()
+	positions, count, err = p.positionRepository.FindAllByCriteria(ctx, criteria)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":      utils.DumpIncomingContext(ctx),
+			"criteria": utils.Dump(criteria),
+		}).Error(err)
+		return nil, 0, err
+	}
+
+	return positions, count, nil
+}
+
+// Update replace the position. The title can't change while employees hold it, ErrPositionInUse is returned then.
+// A narrower band is accepted, it only applies to the next salary changes.
+func (p *positionUsecase) Update(ctx context.Context, id int64, input model.UpdatePositionRequest) (position *model.Position, err error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.DumpIncomingContext(ctx),
+		"id":    id,
+		"input": utils.Dump(input),
+	})
+
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionPositionUpdate) {
+		return nil, ErrPermissionDenied
+	}
+
+	if err := input.Validate(); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	position, err = p.findByID(ctx, id)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	position.Code = strings.ToUpper(strings.TrimSpace(input.Code))
+	position.Title = strings.TrimSpace(input.Title)
+	position.Level = input.Level
+	position.MinSalary = input.MinSalary
+	position.MaxSalary = input.MaxSalary
+	if err := p.checkAvailable(ctx, position); err != nil {
+		return nil, err
+	}
+
+	err = p.positionRepository.Update(ctx, position)
+	switch err {
+	case nil:
+	case model.ErrPositionInUse:
+		return nil, ErrPositionInUse
+	default:
+		logger.Error(err)
+		return nil, err
+	}
+
+	return p.findByID(ctx, id)
+}
+
+// DeleteByID delete the position, ErrPositionInUse is returned while employees hold it
+func (p *positionUsecase) DeleteByID(ctx context.Context, id int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.DumpIncomingContext(ctx),
+		"id":  id,
+	})
+
+	if !auth.GetUserFromCtx(ctx).HasPermission(auth.PermissionPositionDelete) {
+		return ErrPermissionDenied
+	}
+
+	if _, err := p.findByID(ctx, id); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	err := p.positionRepository.Delete(ctx, id)
+	switch err {
+	case nil:
+		return nil
+	case model.ErrPositionInUse:
+		return ErrPositionInUse
+	default:
+		logger.Error(err)
+		return err
+	}
+}
+
+func (p *positionUsecase) findByID(ctx context.Context, id int64) (*model.Position, error) {
+	position, err := p.positionRepository.FindByID(ctx, id)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.DumpIncomingContext(ctx),
+			"id":  id,
+		}).Error(err)
+		return nil, err
+	}
+
+	if position == nil {
+		return nil, ErrNotFound
+	}
+
+	return position, nil
+}
+
+// checkAvailable check that no other position has the code or the title of the position
+func (p *positionUsecase) checkAvailable(ctx context.Context, position *model.Position) error {
+	existing, err := p.positionRepository.FindByCode(ctx, position.Code)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil && existing.ID != position.ID {
+		return ErrDuplicatePosition
+	}
+
+	existing, err = p.positionRepository.FindByTitle(ctx, position.Title)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil && existing.ID != position.ID {
+		return ErrDuplicatePosition
+	}
+
+	return nil
+}
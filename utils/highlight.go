@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// highlightSimilarity is the trigram similarity from which a word is highlighted as a typo of a term
+const highlightSimilarity = 0.4
+
+// Highlight wrap the words of the text matching a term in <mark></mark>, the rest of the text is HTML escaped.
+// A word matches when it starts with a term or when its trigrams are similar enough, so "Sastro" is highlighted
+// for both "sas" and "sasto". The second value is false when no word matches.
+func Highlight(text string, terms []string) (string, bool) {
+	var builder strings.Builder
+	matched := false
+
+	start := -1
+	flush := func(end int) {
+		word := text[start:end]
+		if matchesAnyTerm(word, terms) {
+			matched = true
+			builder.WriteString("<mark>" + html.EscapeString(word) + "</mark>")
+		} else {
+			builder.WriteString(html.EscapeString(word))
+		}
+		start = -1
+	}
+
+	for idx, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = idx
+			}
+			continue
+		}
+
+		if start >= 0 {
+			flush(idx)
+		}
+		builder.WriteString(html.EscapeString(string(r)))
+	}
+	if start >= 0 {
+		flush(len(text))
+	}
+
+	return builder.String(), matched
+}
+
+// SearchTerms split a search query into its lower case words
+func SearchTerms(q string) []string {
+	return strings.FieldsFunc(strings.ToLower(q), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func matchesAnyTerm(word string, terms []string) bool {
+	word = strings.ToLower(word)
+	for _, term := range terms {
+		if strings.HasPrefix(word, term) || TrigramSimilarity(word, term) >= highlightSimilarity {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TrigramSimilarity the similarity of two words as computed by the pg_trgm extension,
+// the number of shared trigrams divided by the number of distinct trigrams of both words
+func TrigramSimilarity(a, b string) float64 {
+	trigramsA, trigramsB := trigrams(a), trigrams(b)
+	if len(trigramsA) == 0 || len(trigramsB) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for trigram := range trigramsA {
+		if trigramsB[trigram] {
+			shared++
+		}
+	}
+
+	return float64(shared) / float64(len(trigramsA)+len(trigramsB)-shared)
+}
+
+// trigrams of a word padded like pg_trgm, two spaces before and one after
+func trigrams(word string) map[string]bool {
+	runes := []rune("  " + strings.ToLower(word) + " ")
+	result := map[string]bool{}
+	for idx := 0; idx+3 <= len(runes); idx++ {
+		result[string(runes[idx:idx+3])] = true
+	}
+
+	return result
+}
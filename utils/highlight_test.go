@@ -0,0 +1,134 @@
+package utils
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		terms       []string
+		want        string
+		wantMatched bool
+	}{
+		{
+			name:        "a prefix",
+			text:        "Budi Sastro",
+			terms:       []string{"sas"},
+			want:        "Budi <mark>Sastro</mark>",
+			wantMatched: true,
+		},
+		{
+			name:        "a typo",
+			text:        "Budi Sastro",
+			terms:       []string{"sasto"},
+			want:        "Budi <mark>Sastro</mark>",
+			wantMatched: true,
+		},
+		{
+			name:        "several terms",
+			text:        "Senior Software Engineer",
+			terms:       []string{"senior", "eng"},
+			want:        "<mark>Senior</mark> Software <mark>Engineer</mark>",
+			wantMatched: true,
+		},
+		{
+			name:        "no match",
+			text:        "Budi Sastro",
+			terms:       []string{"xyz"},
+			want:        "Budi Sastro",
+			wantMatched: false,
+		},
+		{
+			name:        "the text is HTML escaped",
+			text:        "R&D <Lead>",
+			terms:       []string{"lead"},
+			want:        "R&amp;D &lt;<mark>Lead</mark>&gt;",
+			wantMatched: true,
+		},
+		{
+			name:        "the words are split on the punctuation",
+			text:        "Jean-Luc O'Neil",
+			terms:       []string{"luc"},
+			want:        "Jean-<mark>Luc</mark> O&#39;Neil",
+			wantMatched: true,
+		},
+		{
+			name:        "multibyte letters",
+			text:        "José Müller",
+			terms:       []string{"mül"},
+			want:        "José <mark>Müller</mark>",
+			wantMatched: true,
+		},
+		{
+			name:        "digits are part of a word",
+			text:        "Sales Rep 2",
+			terms:       []string{"2"},
+			want:        "Sales Rep <mark>2</mark>",
+			wantMatched: true,
+		},
+		{
+			name:        "no term",
+			text:        "Budi",
+			want:        "Budi",
+			wantMatched: false,
+		},
+		{
+			name:        "an empty text",
+			terms:       []string{"budi"},
+			want:        "",
+			wantMatched: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, matched := Highlight(tt.text, tt.terms)
+			if got != tt.want || matched != tt.wantMatched {
+				t.Errorf("Highlight(%q, %q) = %q, %v, want %q, %v", tt.text, tt.terms, got, matched, tt.want, tt.wantMatched)
+			}
+		})
+	}
+}
+
+func TestSearchTerms(t *testing.T) {
+	tests := []struct {
+		q    string
+		want []string
+	}{
+		{q: "Budi Sastro", want: []string{"budi", "sastro"}},
+		{q: "  R&D, lead!", want: []string{"r", "d", "lead"}},
+		{q: "José 2", want: []string{"josé", "2"}},
+		{q: " ,. ", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.q, func(t *testing.T) {
+			if got := SearchTerms(tt.q); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchTerms(%q) = %q, want %q", tt.q, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrigramSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{a: "sastro", b: "sastro", want: 1},
+		{a: "Sastro", b: "sastro", want: 1},
+		// "  s", " sa", "sas" and "ast" are 4 of the 9 distinct trigrams of both words
+		{a: "sastro", b: "sasto", want: 4.0 / 9},
+		{a: "abc", b: "xyz", want: 0},
+		{a: "", b: "abc", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := TrigramSimilarity(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("TrigramSimilarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
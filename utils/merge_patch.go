@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// MergePatch applies the RFC 7396 JSON merge patch to the target document and returns the result.
+// A member of the patch set to null removes the member from the target,
+// an object is merged recursively and any other value replaces the target member.
+func MergePatch(target, patch []byte) ([]byte, error) {
+	targetDoc, err := decodeJSON(target)
+	if err != nil {
+		return nil, err
+	}
+
+	patchDoc, err := decodeJSON(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(mergePatch(targetDoc, patchDoc))
+}
+
+func mergePatch(target, patch any) any {
+	patchObj, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObj, ok := target.(map[string]any)
+	if !ok {
+		targetObj = map[string]any{}
+	}
+
+	for key, value := range patchObj {
+		if value == nil {
+			delete(targetObj, key)
+			continue
+		}
+		targetObj[key] = mergePatch(targetObj[key], value)
+	}
+
+	return targetObj
+}
+
+func decodeJSON(doc []byte) (out any, err error) {
+	decoder := json.NewDecoder(bytes.NewReader(doc))
+	decoder.UseNumber()
+	err = decoder.Decode(&out)
+	return
+}
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergePatch(t *testing.T) {
+	// the cases of the appendix A of RFC 7396
+	tests := []struct {
+		name   string
+		target string
+		patch  string
+		want   string
+	}{
+		{name: "replace a member", target: `{"a":"b"}`, patch: `{"a":"c"}`, want: `{"a":"c"}`},
+		{name: "add a member", target: `{"a":"b"}`, patch: `{"b":"c"}`, want: `{"a":"b","b":"c"}`},
+		{name: "remove a member", target: `{"a":"b"}`, patch: `{"a":null}`, want: `{}`},
+		{name: "remove one of the members", target: `{"a":"b","b":"c"}`, patch: `{"a":null}`, want: `{"b":"c"}`},
+		{name: "replace an array", target: `{"a":["b"]}`, patch: `{"a":"c"}`, want: `{"a":"c"}`},
+		{name: "replace by an array", target: `{"a":"c"}`, patch: `{"a":["b"]}`, want: `{"a":["b"]}`},
+		{name: "merge a nested object", target: `{"a":{"b":"c"}}`, patch: `{"a":{"b":"d","c":null}}`, want: `{"a":{"b":"d"}}`},
+		{name: "replace an array of objects", target: `{"a":[{"b":"c"}]}`, patch: `{"a":[1]}`, want: `{"a":[1]}`},
+		{name: "replace an array document", target: `["a","b"]`, patch: `["c","d"]`, want: `["c","d"]`},
+		{name: "replace a document by an array", target: `{"a":"b"}`, patch: `["c"]`, want: `["c"]`},
+		{name: "replace a document by null", target: `{"a":"foo"}`, patch: `null`, want: `null`},
+		{name: "replace a document by a string", target: `{"a":"foo"}`, patch: `"bar"`, want: `"bar"`},
+		{name: "keep a null of the target", target: `{"e":null}`, patch: `{"a":1}`, want: `{"a":1,"e":null}`},
+		{name: "replace an array document by an object", target: `[1,2]`, patch: `{"a":"b","c":null}`, want: `{"a":"b"}`},
+		{name: "merge into a missing object", target: `{}`, patch: `{"a":{"bb":{"ccc":null}}}`, want: `{"a":{"bb":{}}}`},
+		{name: "keep the numbers exact", target: `{"salary":"1.00"}`, patch: `{"salary":12345678901234567890}`, want: `{"salary":12345678901234567890}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MergePatch([]byte(tt.target), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("MergePatch() error = %v", err)
+			}
+
+			if !jsonEqual(t, got, []byte(tt.want)) {
+				t.Errorf("MergePatch(%s, %s) = %s, want %s", tt.target, tt.patch, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergePatch_InvalidJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		patch  string
+	}{
+		{name: "invalid target", target: `{"a":`, patch: `{}`},
+		{name: "invalid patch", target: `{}`, patch: `{"a"}`},
+		{name: "empty patch", target: `{}`, patch: ``},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := MergePatch([]byte(tt.target), []byte(tt.patch)); err == nil {
+				t.Errorf("MergePatch(%s, %s) = %s, want an error", tt.target, tt.patch, got)
+			}
+		})
+	}
+}
+
+func jsonEqual(t *testing.T, a, b []byte) bool {
+	t.Helper()
+
+	docA, err := decodeJSON(a)
+	if err != nil {
+		t.Fatalf("invalid JSON %s: %v", a, err)
+	}
+
+	docB, err := decodeJSON(b)
+	if err != nil {
+		t.Fatalf("invalid JSON %s: %v", b, err)
+	}
+
+	return reflect.DeepEqual(docA, docB)
+}
@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 )
 
 // GenerateID based on current time
@@ -28,9 +29,63 @@ func Dump(i any) string {
 	return string(ToByte(i))
 }
 
+// redactedValue replaces the value of a sensitive key in DumpRedacted
+const redactedValue = "[REDACTED]"
+
+// sensitiveKeywords are matched against the lowercased JSON keys in DumpRedacted,
+// a key containing any of them has its value redacted, e.g. salary, salary_gte, amount
+var sensitiveKeywords = []string{"salary", "amount"}
+
+// DumpRedacted to json like Dump, but the value of every sensitive key is redacted at any depth.
+// Use it for values written to the logs.
+func DumpRedacted(i any) string {
+	var v any
+	if err := json.Unmarshal(ToByte(i), &v); err != nil {
+		return Dump(i)
+	}
+
+	return Dump(redact(v))
+}
+
+func redact(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for key, item := range val {
+			if isSensitiveKey(key) {
+				val[key] = redactedValue
+				continue
+			}
+			val[key] = redact(item)
+		}
+		return val
+	case []any:
+		for idx, item := range val {
+			val[idx] = redact(item)
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, keyword := range sensitiveKeywords {
+		if strings.Contains(key, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
 // DumpIncomingContext converts the metadata from the incoming context to a string representation using json marshal.
+// The authorization metadata is omitted so bearer tokens never end up in the logs.
 func DumpIncomingContext(c context.Context) string {
 	md, _ := metadata.FromIncomingContext(c)
+	if md.Len() > 0 {
+		md = md.Copy()
+		delete(md, "authorization")
+	}
 	return Dump(md)
 }
 
@@ -61,6 +116,21 @@ func StringToInt64(s string) int64 {
 	return i
 }
 
+// ToSnakeCase converts a CamelCase identifier, e.g. a struct field name, to snake_case
+func ToSnakeCase(s string) string {
+	var sb strings.Builder
+	for idx, r := range s {
+		if unicode.IsUpper(r) {
+			if idx > 0 {
+				sb.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
 // Int64ToString takes an int64 number and converts it to a string representation
 func Int64ToString(i int64) string {
 	s := strconv.FormatInt(i, 10)